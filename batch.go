@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+type batchOutput struct {
+	Date          frankfurter.YMD      `json:"date"`
+	EffectiveDate *frankfurter.YMD     `json:"effective_date,omitempty"`
+	Base          frankfurter.Currency `json:"base,omitempty"`
+	Rates         frankfurter.Rates    `json:"rates,omitempty"`
+	Error         string               `json:"error,omitempty"`
+}
+
+// batchCommand resolves the rates of many dates, read from a file with one date per line, and prints one JSON object
+// per date.
+func batchCommand() *cobra.Command {
+	var (
+		service    = frankfurter.ExchangeRatesService{HttpClient: http.DefaultClient}
+		options    frankfurter.BatchOptions
+		currencies []string
+	)
+
+	command := &cobra.Command{
+		Use:   "batch [file]",
+		Short: "Fetches the rates for each date in the given file (one YYYY-MM-DD per line; stdin if omitted)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			input := cmd.InOrStdin()
+
+			if len(args) == 1 && args[0] != "-" {
+				file, err := os.Open(args[0])
+
+				if err != nil {
+					return err
+				}
+
+				defer file.Close()
+				input = file
+			}
+
+			dates, err := readDates(input)
+
+			if err != nil {
+				return err
+			}
+
+			symbols := make([]frankfurter.Currency, len(currencies))
+
+			for i, c := range currencies {
+				symbols[i] = frankfurter.Currency(c)
+			}
+
+			results := service.AtEach(cmd.Context(), dates, options, symbols...)
+
+			encoder := json.NewEncoder(cmd.OutOrStdout())
+			var failed int
+
+			for _, result := range results {
+				output := batchOutput{Date: result.Date}
+
+				if result.Err != nil {
+					output.Error = result.Err.Error()
+					failed++
+				} else {
+					output.EffectiveDate = &result.Rates.Date
+					output.Base = result.Rates.Base
+					output.Rates = result.Rates.Rates
+				}
+
+				err = encoder.Encode(output)
+
+				if err != nil {
+					return err
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("unable to fetch rates for %d of %d date(s)", failed, len(results))
+			}
+
+			return nil
+		},
+	}
+
+	command.Flags().StringVar(&service.URL, "url", "https://api.frankfurter.app", "base URL of the Frankfurter API")
+	command.Flags().StringSliceVar(&currencies, "currencies", nil, "currencies to fetch; all if omitted")
+	command.Flags().IntVar(&options.Workers, "workers", 4, "number of concurrent requests")
+	command.Flags().Float64Var(&options.RequestsPerSecond, "rps", 5, "maximum number of requests per second; 0 for unlimited")
+
+	return command
+}
+
+// readDates parses one date per line, skipping blank lines and comments starting with #
+func readDates(r io.Reader) ([]frankfurter.YMD, error) {
+	var dates []frankfurter.YMD
+
+	scanner := bufio.NewScanner(r)
+
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		date, err := frankfurter.NewYMD(text)
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		dates = append(dates, date)
+	}
+
+	return dates, scanner.Err()
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Batch", func() {
+	Describe("readDates", func() {
+		var (
+			input string
+			dates []frankfurter.YMD
+			err   error
+		)
+
+		JustBeforeEach(func() {
+			dates, err = readDates(strings.NewReader(input))
+		})
+
+		Context("blank lines and comments", func() {
+			BeforeEach(func() {
+				input = "# invoices of January\n2024-01-15\n\n   \n  # weekend\n2024-01-13\n"
+			})
+
+			It("works", func() {
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("skips them", func() {
+				Expect(dates).To(HaveLen(2))
+				Expect(dates[0].String()).To(Equal("2024-01-15"))
+				Expect(dates[1].String()).To(Equal("2024-01-13"))
+			})
+		})
+
+		Context("a malformed date", func() {
+			BeforeEach(func() {
+				input = "2024-01-15\n\n2024-13-01\n"
+			})
+
+			It("names the line", func() {
+				Expect(err).To(MatchError(HavePrefix("line 3: ")))
+			})
+		})
+	})
+
+	Describe("command", func() {
+		var (
+			server *httptest.Server
+			input  string
+			stdout *bytes.Buffer
+			err    error
+		)
+
+		BeforeEach(func() {
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/2024-01-15":
+					fmt.Fprintln(w, `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "USD": 1.0882 } }`)
+				case "/2024-01-13":
+					fmt.Fprintln(w, `{ "amount": 1.0, "base": "EUR", "date": "2024-01-12", "rates": { "USD": 1.0969 } }`)
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+
+			input = "2024-01-15\n2024-01-13\n"
+			stdout = &bytes.Buffer{}
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		JustBeforeEach(func() {
+			command := batchCommand()
+			command.SetArgs([]string{"--url", server.URL, "--rps", "0"})
+			command.SetIn(strings.NewReader(input))
+			command.SetOut(stdout)
+			command.SetErr(GinkgoWriter)
+
+			err = command.Execute()
+		})
+
+		It("works", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("prints a line of JSON per date in chronological order", func() {
+			lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+
+			Expect(lines).To(HaveLen(2))
+			Expect(lines[0]).To(MatchJSON(`{ "date": "2024-01-13", "effective_date": "2024-01-12", "base": "EUR", "rates": { "USD": 1.0969 } }`))
+			Expect(lines[1]).To(MatchJSON(`{ "date": "2024-01-15", "effective_date": "2024-01-15", "base": "EUR", "rates": { "USD": 1.0882 } }`))
+		})
+
+		Context("some dates fail", func() {
+			BeforeEach(func() {
+				input = "2024-01-15\n2024-01-16\n"
+			})
+
+			It("still prints the other dates", func() {
+				Expect(stdout.String()).To(ContainSubstring(`"date":"2024-01-15"`))
+			})
+
+			It("prints the error of the failed date", func() {
+				Expect(stdout.String()).To(MatchRegexp(`"date":"2024-01-16","error":".+"`))
+			})
+
+			It("fails, so that the process exits with a non-zero status", func() {
+				Expect(err).To(MatchError("unable to fetch rates for 1 of 2 date(s)"))
+			})
+		})
+
+		Context("a malformed date", func() {
+			BeforeEach(func() {
+				input = "2024-01-15\nyesterday\n"
+			})
+
+			It("fails without fetching anything", func() {
+				Expect(err).To(MatchError(HavePrefix("line 2: ")))
+				Expect(stdout.String()).ToNot(ContainSubstring(`"date"`))
+			})
+		})
+	})
+})
@@ -0,0 +1,169 @@
+// Package calendar knows the TARGET closing days, on which the ECB does not publish euro reference rates.
+//
+// [TARGET closing days]: https://www.ecb.europa.eu/paym/target/t2/html/index.en.html
+package calendar
+
+import (
+	"time"
+	_ "time/tzdata" // Frankfurt must not depend on the time zone database of the host
+)
+
+// PublicationTime is the time of day (in Frankfurt) at which the ECB publishes its reference rates:
+//
+// "The reference rates are usually updated at around 16:00 CET every working day, except on TARGET closing days."
+const PublicationTime = 16 * time.Hour
+
+// MaxPublicationGap is the largest number of calendar days between two consecutive publications of the ECB, e.g. from
+// Maundy Thursday to the Tuesday after Easter. Custom holidays may make it longer.
+const MaxPublicationGap = 5
+
+// Frankfurt is the time zone of the ECB
+var Frankfurt = frankfurt()
+
+func frankfurt() *time.Location {
+	location, err := time.LoadLocation("Europe/Berlin")
+
+	if err != nil {
+		panic(err) // cannot happen with the embedded time zone database
+	}
+
+	return location
+}
+
+type day struct {
+	year  int
+	month time.Month
+	day   int
+}
+
+func dayOf(t time.Time) day {
+	y, m, d := t.Date()
+	return day{y, m, d}
+}
+
+// Calendar tells business days from closing days. The zero value is not usable; use TARGET to create one.
+type Calendar struct {
+	holidays map[day]string
+}
+
+// TARGET returns a calendar with the TARGET closing days. Additional holidays may be added with AddHoliday.
+func TARGET() *Calendar {
+	return &Calendar{holidays: make(map[day]string)}
+}
+
+// AddHoliday adds a custom closing day on the day of t. It does not affect other calendars.
+func (c *Calendar) AddHoliday(t time.Time, name string) {
+	c.holidays[dayOf(t)] = name
+}
+
+// Closure returns the name of the closing day that t falls on, and whether it is a closing day at all.
+func (c *Calendar) Closure(t time.Time) (string, bool) {
+	switch t.Weekday() {
+	case time.Saturday, time.Sunday:
+		return t.Weekday().String(), true
+	}
+
+	if name, found := c.holidays[dayOf(t)]; found {
+		return name, true
+	}
+
+	return targetHoliday(t)
+}
+
+// IsClosingDay tells whether TARGET is closed on the day of t.
+func (c *Calendar) IsClosingDay(t time.Time) bool {
+	_, closed := c.Closure(t)
+	return closed
+}
+
+// IsBusinessDay tells whether TARGET is open on the day of t.
+func (c *Calendar) IsBusinessDay(t time.Time) bool {
+	return !c.IsClosingDay(t)
+}
+
+// Next returns the first business day after the day of t, keeping the time of day.
+func (c *Calendar) Next(t time.Time) time.Time {
+	return c.step(t, 1)
+}
+
+// Previous returns the last business day before the day of t, keeping the time of day.
+func (c *Calendar) Previous(t time.Time) time.Time {
+	return c.step(t, -1)
+}
+
+func (c *Calendar) step(t time.Time, days int) time.Time {
+	t = t.AddDate(0, 0, days)
+
+	for c.IsClosingDay(t) {
+		t = t.AddDate(0, 0, days)
+	}
+
+	return t
+}
+
+// Each calls f for every business day from the day of start until the day of end (both inclusive), in chronological
+// order. Iteration stops early if f returns false.
+func (c *Calendar) Each(start, end time.Time, f func(time.Time) bool) {
+	last := dayOf(end)
+
+	for t := start; !after(dayOf(t), last); t = t.AddDate(0, 0, 1) {
+		if c.IsClosingDay(t) {
+			continue
+		}
+
+		if !f(t) {
+			return
+		}
+	}
+}
+
+// BusinessDays returns all business days from the day of start until the day of end (both inclusive).
+func (c *Calendar) BusinessDays(start, end time.Time) []time.Time {
+	var days []time.Time
+
+	c.Each(start, end, func(t time.Time) bool {
+		days = append(days, t)
+		return true
+	})
+
+	return days
+}
+
+// NextPublication returns the first point in time after t at which reference rates are published.
+func (c *Calendar) NextPublication(t time.Time) time.Time {
+	publication := publicationOn(t)
+
+	if publication.After(t) && c.IsBusinessDay(publication) {
+		return publication
+	}
+
+	return c.Next(publication)
+}
+
+// PreviousPublication returns the last point in time at or before t at which reference rates were published.
+func (c *Calendar) PreviousPublication(t time.Time) time.Time {
+	publication := publicationOn(t)
+
+	if !publication.After(t) && c.IsBusinessDay(publication) {
+		return publication
+	}
+
+	return c.Previous(publication)
+}
+
+func publicationOn(t time.Time) time.Time {
+	y, m, d := t.In(Frankfurt).Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, Frankfurt).Add(PublicationTime)
+}
+
+func after(d, e day) bool {
+	if d.year != e.year {
+		return d.year > e.year
+	}
+
+	if d.month != e.month {
+		return d.month > e.month
+	}
+
+	return d.day > e.day
+}
@@ -0,0 +1,139 @@
+package calendar_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/euro-exchange-rates-resource/calendar"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, calendar.Frankfurt)
+}
+
+var _ = Describe("Calendar", func() {
+	var target *calendar.Calendar
+
+	BeforeEach(func() {
+		target = calendar.TARGET()
+	})
+
+	DescribeTable("closing days",
+		func(t time.Time, name string) {
+			closure, closed := target.Closure(t)
+			Expect(closed).To(BeTrue())
+			Expect(closure).To(Equal(name))
+		},
+		Entry("Saturday", date(2024, time.January, 13), "Saturday"),
+		Entry("Sunday", date(2024, time.January, 14), "Sunday"),
+		Entry("New Year's Day", date(2024, time.January, 1), "New Year's Day"),
+		Entry("Good Friday", date(2024, time.March, 29), "Good Friday"),
+		Entry("Easter Monday", date(2024, time.April, 1), "Easter Monday"),
+		Entry("Labour Day", date(2024, time.May, 1), "Labour Day"),
+		Entry("Christmas Day", date(2024, time.December, 25), "Christmas Day"),
+		Entry("26 December", date(2024, time.December, 26), "Christmas Holiday"),
+		Entry("historical New Year's Eve", date(2001, time.December, 31), "New Year's Eve"),
+	)
+
+	DescribeTable("business days",
+		func(t time.Time) {
+			Expect(target.IsBusinessDay(t)).To(BeTrue())
+		},
+		Entry("regular Monday", date(2024, time.January, 15)),
+		Entry("New Year's Eve nowadays", date(2024, time.December, 31)),
+		Entry("Good Friday before 2000", date(1999, time.April, 2)),
+		Entry("26 December before 2000", date(1997, time.December, 26)),
+	)
+
+	Describe("Next", func() {
+		It("skips the Easter weekend", func() {
+			Expect(target.Next(date(2024, time.March, 28))).To(Equal(date(2024, time.April, 2)))
+		})
+
+		It("keeps the time of day", func() {
+			Expect(target.Next(date(2024, time.January, 15).Add(16 * time.Hour)).Hour()).To(Equal(16))
+		})
+	})
+
+	Describe("Previous", func() {
+		It("skips Christmas and the weekend", func() {
+			Expect(target.Previous(date(2023, time.December, 27))).To(Equal(date(2023, time.December, 22)))
+		})
+	})
+
+	Describe("BusinessDays", func() {
+		It("enumerates the business days of a week with a holiday", func() {
+			Expect(target.BusinessDays(date(2024, time.April, 29), date(2024, time.May, 5))).To(Equal([]time.Time{
+				date(2024, time.April, 29),
+				date(2024, time.April, 30),
+				date(2024, time.May, 2),
+				date(2024, time.May, 3),
+			}))
+		})
+
+		It("is empty for a weekend", func() {
+			Expect(target.BusinessDays(date(2024, time.May, 4), date(2024, time.May, 5))).To(BeEmpty())
+		})
+	})
+
+	Describe("Each", func() {
+		It("stops when asked to", func() {
+			var seen int
+
+			target.Each(date(2024, time.January, 1), date(2024, time.December, 31), func(time.Time) bool {
+				seen++
+				return seen < 3
+			})
+
+			Expect(seen).To(Equal(3))
+		})
+	})
+
+	Describe("publications", func() {
+		It("expects today's publication if it is still ahead", func() {
+			Expect(target.NextPublication(date(2024, time.January, 15).Add(9 * time.Hour))).To(Equal(date(2024, time.January, 15).Add(16 * time.Hour)))
+		})
+
+		It("expects the next business day's publication after today's", func() {
+			Expect(target.NextPublication(date(2024, time.January, 19).Add(17 * time.Hour))).To(Equal(date(2024, time.January, 22).Add(16 * time.Hour)))
+		})
+
+		It("finds the previous publication before today's", func() {
+			Expect(target.PreviousPublication(date(2024, time.January, 15).Add(9 * time.Hour))).To(Equal(date(2024, time.January, 12).Add(16 * time.Hour)))
+		})
+
+		It("finds today's publication once it happened", func() {
+			Expect(target.PreviousPublication(date(2024, time.January, 15).Add(16 * time.Hour))).To(Equal(date(2024, time.January, 15).Add(16 * time.Hour)))
+		})
+	})
+
+	It("never has more than MaxPublicationGap days between two business days", func() {
+		var previous time.Time
+
+		target.Each(date(1999, time.January, 4), date(2050, time.December, 31), func(t time.Time) bool {
+			if !previous.IsZero() {
+				Expect(t).ToNot(BeTemporally(">", previous.AddDate(0, 0, calendar.MaxPublicationGap)), "after %s", previous.Format(time.DateOnly))
+			}
+
+			previous = t
+			return true
+		})
+	})
+
+	Describe("custom holidays", func() {
+		BeforeEach(func() {
+			target.AddHoliday(date(2024, time.October, 3), "German Unity Day")
+		})
+
+		It("closes on the custom holiday", func() {
+			closure, closed := target.Closure(date(2024, time.October, 3))
+			Expect(closed).To(BeTrue())
+			Expect(closure).To(Equal("German Unity Day"))
+		})
+
+		It("does not affect other calendars", func() {
+			Expect(calendar.TARGET().IsBusinessDay(date(2024, time.October, 3))).To(BeTrue())
+		})
+	})
+})
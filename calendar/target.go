@@ -0,0 +1,66 @@
+package calendar
+
+import "time"
+
+// historicalClosures are closing days that were not part of the regular TARGET calendar
+var historicalClosures = map[day]string{
+	{1999, time.December, 31}: "New Year's Eve",
+	{2000, time.December, 31}: "New Year's Eve",
+	{2001, time.December, 31}: "New Year's Eve",
+}
+
+// targetHoliday returns the name of the TARGET holiday on the day of t, if any.
+//
+// Until 1999, TARGET was closed only on New Year's Day and Christmas Day. Good Friday, Easter Monday, Labour Day and
+// 26 December were added in 2000.
+func targetHoliday(t time.Time) (string, bool) {
+	if name, found := historicalClosures[dayOf(t)]; found {
+		return name, true
+	}
+
+	year, month, d := t.Date()
+
+	switch {
+	case month == time.January && d == 1:
+		return "New Year's Day", true
+	case month == time.December && d == 25:
+		return "Christmas Day", true
+	case year < 2000:
+		return "", false
+	case month == time.May && d == 1:
+		return "Labour Day", true
+	case month == time.December && d == 26:
+		return "Christmas Holiday", true
+	}
+
+	easterSunday := easter(year)
+
+	switch dayOf(t) {
+	case dayOf(easterSunday.AddDate(0, 0, -2)):
+		return "Good Friday", true
+	case dayOf(easterSunday.AddDate(0, 0, 1)):
+		return "Easter Monday", true
+	}
+
+	return "", false
+}
+
+// easter computes the date of Easter Sunday in the given year (Gregorian calendar, anonymous algorithm)
+func easter(year int) time.Time {
+	a := year % 19
+	b := year / 100
+	c := year % 100
+	d := b / 4
+	e := b % 4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i := c / 4
+	k := c % 4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
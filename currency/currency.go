@@ -0,0 +1,55 @@
+// Package currency describes the currencies for which the ECB publishes (or used to publish) reference rates, using
+// the data of ISO 4217, and rounds amounts to their minor units.
+//
+// [ISO 4217]: https://www.iso.org/iso-4217-currency-codes.html
+package currency
+
+import (
+	"cmp"
+	"math/big"
+	"slices"
+)
+
+// Currency is an ISO 4217 currency
+type Currency struct {
+	Code       string // alphabetic code, e.g. USD
+	Numeric    string // numeric code, e.g. 840
+	Name       string
+	MinorUnits int    // number of decimals, e.g. 2 for cents
+	Symbol     string // a common symbol, e.g. $
+	Withdrawn  bool   // replaced by another currency (often the euro)
+}
+
+// Lookup returns the currency with the given alphabetic code
+func Lookup(code string) (Currency, bool) {
+	currency, found := iso4217[code]
+	return currency, found
+}
+
+// All returns all known currencies, ordered by code
+func All() []Currency {
+	all := make([]Currency, 0, len(iso4217))
+
+	for _, currency := range iso4217 {
+		all = append(all, currency)
+	}
+
+	slices.SortFunc(all, func(a, b Currency) int { return cmp.Compare(a.Code, b.Code) })
+
+	return all
+}
+
+// Round rounds the amount to the minor units of the currency
+func (c Currency) Round(amount *big.Rat, mode RoundingMode) *big.Rat {
+	return Round(amount, c.MinorUnits, mode)
+}
+
+// Format rounds the amount to the minor units of the currency and formats it with exactly as many decimals, e.g.
+// "10.80" for USD or "1604" for JPY.
+func (c Currency) Format(amount *big.Rat, mode RoundingMode) string {
+	return c.Round(amount, mode).FloatString(c.MinorUnits)
+}
+
+func (c Currency) String() string {
+	return c.Code
+}
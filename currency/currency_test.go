@@ -0,0 +1,89 @@
+package currency_test
+
+import (
+	"math/big"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/euro-exchange-rates-resource/currency"
+)
+
+func rat(s string) *big.Rat {
+	r, ok := new(big.Rat).SetString(s)
+	Expect(ok).To(BeTrue())
+	return r
+}
+
+var _ = Describe("Currency", func() {
+	Describe("Lookup", func() {
+		It("knows the US Dollar", func() {
+			usd, found := currency.Lookup("USD")
+			Expect(found).To(BeTrue())
+			Expect(usd).To(Equal(currency.Currency{Code: "USD", Numeric: "840", Name: "US Dollar", MinorUnits: 2, Symbol: "$"}))
+		})
+
+		It("knows that the yen has no minor units", func() {
+			jpy, _ := currency.Lookup("JPY")
+			Expect(jpy.MinorUnits).To(Equal(0))
+		})
+
+		It("knows withdrawn currencies", func() {
+			skk, found := currency.Lookup("SKK")
+			Expect(found).To(BeTrue())
+			Expect(skk.Withdrawn).To(BeTrue())
+		})
+
+		It("does not know made-up currencies", func() {
+			_, found := currency.Lookup("XYZ")
+			Expect(found).To(BeFalse())
+		})
+	})
+
+	Describe("All", func() {
+		It("is ordered by code", func() {
+			all := currency.All()
+			Expect(all[0].Code).To(Equal("AUD"))
+			Expect(all[len(all)-1].Code).To(Equal("ZAR"))
+		})
+	})
+
+	DescribeTable("Round",
+		func(x string, mode currency.RoundingMode, expected string) {
+			Expect(currency.Round(rat(x), 2, mode).FloatString(2)).To(Equal(expected))
+		},
+		Entry("half-up rounds ties away from zero", "2.345", currency.HalfUp, "2.35"),
+		Entry("half-up rounds negative ties away from zero", "-2.345", currency.HalfUp, "-2.35"),
+		Entry("half-up rounds below ties down", "2.3449", currency.HalfUp, "2.34"),
+		Entry("half-even rounds ties to even", "2.345", currency.HalfEven, "2.34"),
+		Entry("half-even rounds odd ties up", "2.355", currency.HalfEven, "2.36"),
+		Entry("half-even rounds above ties up", "2.3451", currency.HalfEven, "2.35"),
+		Entry("down truncates", "2.349", currency.Down, "2.34"),
+		Entry("down truncates towards zero", "-2.349", currency.Down, "-2.34"),
+		Entry("up rounds away from zero", "2.341", currency.Up, "2.35"),
+		Entry("up rounds negatives away from zero", "-2.341", currency.Up, "-2.35"),
+		Entry("exact values stay", "2.34", currency.Up, "2.34"),
+	)
+
+	Describe("Format", func() {
+		It("pads to the minor units", func() {
+			usd, _ := currency.Lookup("USD")
+			Expect(usd.Format(rat("10.8"), currency.HalfUp)).To(Equal("10.80"))
+		})
+
+		It("has no decimals without minor units", func() {
+			jpy, _ := currency.Lookup("JPY")
+			Expect(jpy.Format(rat("1603.5948"), currency.HalfUp)).To(Equal("1604"))
+		})
+	})
+
+	Describe("ParseRoundingMode", func() {
+		It("parses the names", func() {
+			Expect(currency.ParseRoundingMode("half-even")).To(Equal(currency.HalfEven))
+		})
+
+		It("rejects unknown names", func() {
+			_, err := currency.ParseRoundingMode("ceiling")
+			Expect(err).To(MatchError(ContainSubstring(`unknown rounding mode "ceiling"`)))
+		})
+	})
+})
@@ -0,0 +1,57 @@
+package currency
+
+// iso4217 has the currencies that the ECB publishes reference rates for, including those it no longer publishes.
+var iso4217 = index(
+	Currency{Code: "AUD", Numeric: "036", Name: "Australian Dollar", MinorUnits: 2, Symbol: "A$"},
+	Currency{Code: "BGN", Numeric: "975", Name: "Bulgarian Lev", MinorUnits: 2, Symbol: "лв"},
+	Currency{Code: "BRL", Numeric: "986", Name: "Brazilian Real", MinorUnits: 2, Symbol: "R$"},
+	Currency{Code: "CAD", Numeric: "124", Name: "Canadian Dollar", MinorUnits: 2, Symbol: "CA$"},
+	Currency{Code: "CHF", Numeric: "756", Name: "Swiss Franc", MinorUnits: 2, Symbol: "CHF"},
+	Currency{Code: "CNY", Numeric: "156", Name: "Yuan Renminbi", MinorUnits: 2, Symbol: "CN¥"},
+	Currency{Code: "CYP", Numeric: "196", Name: "Cyprus Pound", MinorUnits: 2, Symbol: "£", Withdrawn: true},
+	Currency{Code: "CZK", Numeric: "203", Name: "Czech Koruna", MinorUnits: 2, Symbol: "Kč"},
+	Currency{Code: "DKK", Numeric: "208", Name: "Danish Krone", MinorUnits: 2, Symbol: "kr."},
+	Currency{Code: "EEK", Numeric: "233", Name: "Kroon", MinorUnits: 2, Symbol: "kr", Withdrawn: true},
+	Currency{Code: "EUR", Numeric: "978", Name: "Euro", MinorUnits: 2, Symbol: "€"},
+	Currency{Code: "GBP", Numeric: "826", Name: "Pound Sterling", MinorUnits: 2, Symbol: "£"},
+	Currency{Code: "HKD", Numeric: "344", Name: "Hong Kong Dollar", MinorUnits: 2, Symbol: "HK$"},
+	Currency{Code: "HRK", Numeric: "191", Name: "Kuna", MinorUnits: 2, Symbol: "kn", Withdrawn: true},
+	Currency{Code: "HUF", Numeric: "348", Name: "Forint", MinorUnits: 2, Symbol: "Ft"},
+	Currency{Code: "IDR", Numeric: "360", Name: "Rupiah", MinorUnits: 2, Symbol: "Rp"},
+	Currency{Code: "ILS", Numeric: "376", Name: "New Israeli Sheqel", MinorUnits: 2, Symbol: "₪"},
+	Currency{Code: "INR", Numeric: "356", Name: "Indian Rupee", MinorUnits: 2, Symbol: "₹"},
+	Currency{Code: "ISK", Numeric: "352", Name: "Iceland Krona", MinorUnits: 0, Symbol: "kr"},
+	Currency{Code: "JPY", Numeric: "392", Name: "Yen", MinorUnits: 0, Symbol: "¥"},
+	Currency{Code: "KRW", Numeric: "410", Name: "Won", MinorUnits: 0, Symbol: "₩"},
+	Currency{Code: "LTL", Numeric: "440", Name: "Lithuanian Litas", MinorUnits: 2, Symbol: "Lt", Withdrawn: true},
+	Currency{Code: "LVL", Numeric: "428", Name: "Latvian Lats", MinorUnits: 2, Symbol: "Ls", Withdrawn: true},
+	Currency{Code: "MTL", Numeric: "470", Name: "Maltese Lira", MinorUnits: 2, Symbol: "Lm", Withdrawn: true},
+	Currency{Code: "MXN", Numeric: "484", Name: "Mexican Peso", MinorUnits: 2, Symbol: "MX$"},
+	Currency{Code: "MYR", Numeric: "458", Name: "Malaysian Ringgit", MinorUnits: 2, Symbol: "RM"},
+	Currency{Code: "NOK", Numeric: "578", Name: "Norwegian Krone", MinorUnits: 2, Symbol: "kr"},
+	Currency{Code: "NZD", Numeric: "554", Name: "New Zealand Dollar", MinorUnits: 2, Symbol: "NZ$"},
+	Currency{Code: "PHP", Numeric: "608", Name: "Philippine Peso", MinorUnits: 2, Symbol: "₱"},
+	Currency{Code: "PLN", Numeric: "985", Name: "Zloty", MinorUnits: 2, Symbol: "zł"},
+	Currency{Code: "ROL", Numeric: "642", Name: "Romanian Leu (old)", MinorUnits: 2, Symbol: "lei", Withdrawn: true},
+	Currency{Code: "RON", Numeric: "946", Name: "Romanian Leu", MinorUnits: 2, Symbol: "lei"},
+	Currency{Code: "RUB", Numeric: "643", Name: "Russian Ruble", MinorUnits: 2, Symbol: "₽"},
+	Currency{Code: "SEK", Numeric: "752", Name: "Swedish Krona", MinorUnits: 2, Symbol: "kr"},
+	Currency{Code: "SGD", Numeric: "702", Name: "Singapore Dollar", MinorUnits: 2, Symbol: "S$"},
+	Currency{Code: "SIT", Numeric: "705", Name: "Tolar", MinorUnits: 2, Symbol: "SIT", Withdrawn: true},
+	Currency{Code: "SKK", Numeric: "703", Name: "Slovak Koruna", MinorUnits: 2, Symbol: "Sk", Withdrawn: true},
+	Currency{Code: "THB", Numeric: "764", Name: "Baht", MinorUnits: 2, Symbol: "฿"},
+	Currency{Code: "TRL", Numeric: "792", Name: "Old Turkish Lira", MinorUnits: 0, Symbol: "TL", Withdrawn: true},
+	Currency{Code: "TRY", Numeric: "949", Name: "Turkish Lira", MinorUnits: 2, Symbol: "₺"},
+	Currency{Code: "USD", Numeric: "840", Name: "US Dollar", MinorUnits: 2, Symbol: "$"},
+	Currency{Code: "ZAR", Numeric: "710", Name: "Rand", MinorUnits: 2, Symbol: "R"},
+)
+
+func index(currencies ...Currency) map[string]Currency {
+	byCode := make(map[string]Currency, len(currencies))
+
+	for _, currency := range currencies {
+		byCode[currency.Code] = currency
+	}
+
+	return byCode
+}
@@ -0,0 +1,171 @@
+package currency
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/text/currency"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"golang.org/x/text/number"
+)
+
+// Locale has the conventions for writing numbers and amounts of money in a language and region. Separators, grouping
+// and currency symbols are those of CLDR as known to golang.org/x/text; e.g. en-IN groups lakhs and crores, and es-MX
+// uses a decimal point unlike es-ES.
+type Locale struct {
+	Tag language.Tag
+
+	printer *message.Printer
+}
+
+// ParseLocale returns the conventions of the locale with the given BCP 47 tag.
+func ParseLocale(s string) (Locale, error) {
+	tag, err := language.Parse(s)
+
+	if err != nil {
+		return Locale{}, fmt.Errorf("unable to parse locale %q: %w", s, err)
+	}
+
+	if tag == language.Und {
+		return Locale{}, fmt.Errorf("there are no conventions for formatting numbers in locale %s", tag)
+	}
+
+	return Locale{Tag: tag, printer: message.NewPrinter(tag)}, nil
+}
+
+// FormatNumber writes a decimal number like "1088.2" (as strconv or big.Rat.FloatString produce it) with the
+// separators of the locale, e.g. "1.088,2" in German. The digits stay exactly as they are.
+func (l Locale) FormatNumber(s string) string {
+	digits, negative := strings.CutPrefix(s, "-")
+	integer, fraction, hasFraction := strings.Cut(digits, ".")
+	i, err := strconv.ParseInt(integer, 10, 64)
+
+	if err != nil {
+		return s
+	}
+
+	formatted := l.printer.Sprint(number.Decimal(i))
+
+	if hasFraction {
+		f, err := strconv.ParseInt(fraction, 10, 64)
+
+		if err != nil {
+			return s
+		}
+
+		formatted += l.decimalSeparator() + l.printer.Sprint(number.Decimal(f, number.NoSeparator(), number.MinIntegerDigits(len(fraction))))
+	}
+
+	if negative {
+		return l.minusSign() + formatted
+	}
+
+	return formatted
+}
+
+// FormatAmount rounds the amount to the minor units of the currency and writes it with the currency symbol in the
+// way of the locale, e.g. "$1,088.20" in English or "1.088,20 $" in German.
+func (l Locale) FormatAmount(amount *big.Rat, c Currency, mode RoundingMode) string {
+	digits, negative := strings.CutPrefix(l.FormatNumber(c.Format(amount, mode)), l.minusSign())
+	symbol := c.Code
+
+	if unit, err := currency.ParseISO(c.Code); err == nil {
+		symbol = l.printer.Sprint(currency.Symbol(unit))
+	}
+
+	pattern := l.currencyPattern()
+	sign := ""
+
+	if negative {
+		sign = l.minusSign()
+	}
+
+	if pattern.prefix {
+		last, _ := utf8.DecodeLastRuneInString(symbol)
+		return sign + symbol + pattern.separator(last) + digits
+	}
+
+	first, _ := utf8.DecodeRuneInString(symbol)
+	return sign + digits + pattern.separator(first) + symbol
+}
+
+func (l Locale) String() string {
+	return l.Tag.String()
+}
+
+// decimalSeparator is what the locale writes between the integer and the fraction of a number
+func (l Locale) decimalSeparator() string {
+	return strings.TrimSuffix(strings.TrimPrefix(l.printer.Sprint(number.Decimal(0.5)), l.printer.Sprint(number.Decimal(0))), l.printer.Sprint(number.Decimal(5)))
+}
+
+// minusSign is what the locale writes in front of negative numbers
+func (l Locale) minusSign() string {
+	return strings.TrimSuffix(l.printer.Sprint(number.Decimal(-1)), l.printer.Sprint(number.Decimal(1)))
+}
+
+// currencyPattern is where CLDR puts the currency symbol in relation to the number
+type currencyPattern struct {
+	prefix bool // symbol before the number
+	space  bool // non-breaking space between symbol and number
+}
+
+const nbsp = "\u00a0"
+
+// separator returns what goes between the number and the symbol, given the character of the symbol next to the
+// number. Like CLDR's currency spacing, a symbol ending in a letter (e.g. "CHF") is always set apart from the number.
+func (p currencyPattern) separator(adjacent rune) string {
+	if p.space || !unicode.IsSymbol(adjacent) {
+		return nbsp
+	}
+
+	return ""
+}
+
+// currencyPatterns are the standard currency formats of CLDR (e.g. "#,##0.00 ¤" for German) for the locales that
+// differ from their parent. golang.org/x/text has the separators and symbols, but not these patterns.
+var currencyPatterns = map[string]currencyPattern{
+	"und":    {prefix: true, space: true},
+	"en":     {prefix: true},
+	"en-150": {space: true},
+	"de":     {space: true},
+	"de-AT":  {prefix: true, space: true},
+	"de-CH":  {prefix: true, space: true},
+	"de-LI":  {prefix: true, space: true},
+	"fr":     {space: true},
+	"it":     {space: true},
+	"it-CH":  {prefix: true, space: true},
+	"nl":     {prefix: true, space: true},
+	"es":     {space: true},
+	"es-419": {prefix: true},
+	"pt":     {prefix: true, space: true},
+	"pt-PT":  {space: true},
+	"sv":     {space: true},
+	"fi":     {space: true},
+	"da":     {space: true},
+	"pl":     {space: true},
+	"cs":     {space: true},
+	"sk":     {space: true},
+	"ru":     {space: true},
+	"ja":     {prefix: true},
+	"zh":     {prefix: true},
+	"ko":     {prefix: true},
+	"tr":     {prefix: true},
+}
+
+// currencyPattern returns the pattern of the locale, or of its closest ancestor as CLDR inherits them
+func (l Locale) currencyPattern() currencyPattern {
+	for tag := l.Tag; ; tag = tag.Parent() {
+		if pattern, found := currencyPatterns[tag.String()]; found {
+			return pattern
+		}
+
+		if tag == language.Und {
+			return currencyPatterns["und"]
+		}
+	}
+}
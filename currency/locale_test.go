@@ -0,0 +1,72 @@
+package currency_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/euro-exchange-rates-resource/currency"
+)
+
+var _ = Describe("Locale", func() {
+	locale := func(s string) currency.Locale {
+		l, err := currency.ParseLocale(s)
+		Expect(err).ToNot(HaveOccurred())
+		return l
+	}
+
+	lookup := func(code string) currency.Currency {
+		c, found := currency.Lookup(code)
+		Expect(found).To(BeTrue())
+		return c
+	}
+
+	DescribeTable("FormatAmount",
+		func(tag, code, amount, expected string) {
+			Expect(locale(tag).FormatAmount(rat(amount), lookup(code), currency.HalfUp)).To(Equal(expected))
+		},
+		Entry("en-US", "en-US", "USD", "1088.2", "$1,088.20"),
+		Entry("de-DE", "de-DE", "USD", "1088.2", "1.088,20\u00a0$"),
+		Entry("de-AT unlike German", "de-AT", "EUR", "1088.2", "€\u00a01\u00a0088,20"),
+		Entry("de-CH", "de-CH", "CHF", "1088.2", "CHF\u00a01’088.20"),
+		Entry("fr-FR", "fr-FR", "EUR", "1088.2", "1\u00a0088,20\u00a0€"),
+		Entry("nl-NL", "nl-NL", "EUR", "1088.2", "€\u00a01.088,20"),
+		Entry("es-ES", "es-ES", "EUR", "1088.2", "1.088,20\u00a0€"),
+		Entry("es-MX unlike es-ES", "es-MX", "MXN", "1234.5", "$1,234.50"),
+		Entry("en-IN with lakhs", "en-IN", "INR", "1234567.5", "₹12,34,567.50"),
+		Entry("en-ZA unlike en-US", "en-ZA", "ZAR", "1234.5", "R\u00a01\u00a0234,50"),
+		Entry("ja-JP without minor units", "ja-JP", "JPY", "160520.4", "￥160,520"),
+		Entry("negative amounts", "en-US", "USD", "-1088.2", "-$1,088.20"),
+		Entry("negative amounts with the minus sign of the locale", "sv-SE", "SEK", "-5.5", "\u22125,50\u00a0kr"),
+		Entry("millions", "de-DE", "SEK", "1234567.891", "1.234.567,89\u00a0SEK"),
+	)
+
+	DescribeTable("FormatNumber",
+		func(tag, number, expected string) {
+			Expect(locale(tag).FormatNumber(number)).To(Equal(expected))
+		},
+		Entry("rate in German", "de", "1.0882", "1,0882"),
+		Entry("rate in English", "en", "160.52", "160.52"),
+		Entry("integer", "de", "7865", "7.865"),
+		Entry("small integer", "de", "865", "865"),
+		Entry("es-MX", "es-MX", "1234.5", "1,234.5"),
+		Entry("en-IN", "en-IN", "1234567.5", "12,34,567.5"),
+		Entry("en-ZA", "en-ZA", "1234.5", "1\u00a0234,5"),
+		Entry("keeps decimals", "en", "1.50", "1.50"),
+		Entry("keeps digits that float64 would lose", "en", "9007199254740993.5", "9,007,199,254,740,993.5"),
+	)
+
+	Describe("ParseLocale", func() {
+		It("keeps the requested tag", func() {
+			Expect(locale("de-AT").String()).To(Equal("de-AT"))
+		})
+
+		It("rejects malformed tags", func() {
+			_, err := currency.ParseLocale("not a locale")
+			Expect(err).To(MatchError(ContainSubstring(`unable to parse locale "not a locale"`)))
+		})
+
+		It("rejects locales without conventions", func() {
+			_, err := currency.ParseLocale("und")
+			Expect(err).To(MatchError(ContainSubstring("there are no conventions for formatting numbers in locale und")))
+		})
+	})
+})
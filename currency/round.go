@@ -0,0 +1,76 @@
+package currency
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// RoundingMode tells how to round an amount that lies between two representable values
+type RoundingMode int
+
+const (
+	HalfUp   RoundingMode = iota // to the nearest value; ties away from zero
+	HalfEven                     // to the nearest value; ties to the even neighbour (banker's rounding)
+	Down                         // towards zero (truncating)
+	Up                           // away from zero
+)
+
+var roundingModes = map[RoundingMode]string{
+	HalfUp:   "half-up",
+	HalfEven: "half-even",
+	Down:     "down",
+	Up:       "up",
+}
+
+// ParseRoundingMode returns the rounding mode with the given name: half-up, half-even, down or up
+func ParseRoundingMode(name string) (RoundingMode, error) {
+	for mode, n := range roundingModes {
+		if n == name {
+			return mode, nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown rounding mode %q; must be one of half-up, half-even, down or up", name)
+}
+
+func (m RoundingMode) String() string {
+	return roundingModes[m]
+}
+
+// Round rounds x to the given number of decimals. Being based on big.Rat, it is exact; there is none of the error
+// that rounding a binary floating point number brings.
+func Round(x *big.Rat, decimals int, mode RoundingMode) *big.Rat {
+	scale := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	scaled := new(big.Rat).Mul(x, new(big.Rat).SetInt(scale))
+
+	quotient, remainder := new(big.Int).QuoRem(scaled.Num(), scaled.Denom(), new(big.Int)) // truncates towards zero
+
+	if remainder.Sign() != 0 && away(mode, quotient, remainder, scaled.Denom()) {
+		quotient.Add(quotient, big.NewInt(int64(x.Sign())))
+	}
+
+	return new(big.Rat).SetFrac(quotient, scale)
+}
+
+// away tells whether the truncated quotient needs to be moved away from zero, given the remainder of the division
+func away(mode RoundingMode, quotient, remainder, denominator *big.Int) bool {
+	switch mode {
+	case Down:
+		return false
+	case Up:
+		return true
+	}
+
+	half := new(big.Int).Mul(new(big.Int).Abs(remainder), big.NewInt(2)).Cmp(denominator)
+
+	switch {
+	case half > 0:
+		return true
+	case half < 0:
+		return false
+	case mode == HalfEven:
+		return quotient.Bit(0) == 1
+	default:
+		return true
+	}
+}
@@ -0,0 +1,226 @@
+package euroexchangerates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+// Band is a corridor for the rate of a single currency. Either bound may be omitted, but not both.
+//
+// When bands are configured, Check only emits versions for the dates on which the rate of at least one currency
+// entered or left its band.
+type Band struct {
+	Lower *float32 `json:"lower"`
+	Upper *float32 `json:"upper"`
+}
+
+type bandState string
+
+const (
+	belowBand  bandState = "below"
+	withinBand bandState = "within"
+	aboveBand  bandState = "above"
+)
+
+func (b Band) state(rate float32) bandState {
+	if b.Lower != nil && rate < *b.Lower {
+		return belowBand
+	}
+
+	if b.Upper != nil && rate > *b.Upper {
+		return aboveBand
+	}
+
+	return withinBand
+}
+
+func validateBands(source Source) error {
+	for currency, band := range source.Bands {
+		if band.Lower == nil && band.Upper == nil {
+			return fmt.Errorf("band for %s has neither a lower nor an upper bound", currency)
+		}
+
+		if band.Lower != nil && band.Upper != nil && *band.Lower > *band.Upper {
+			return fmt.Errorf("band for %s has a lower bound %s above its upper bound %s", currency, rateString(*band.Lower), rateString(*band.Upper))
+		}
+
+		if len(source.Currencies) > 0 && !slices.Contains(source.Currencies, currency) {
+			return fmt.Errorf("band for %s configured, but %s is not among the configured currencies %s", currency, currency, source.Currencies)
+		}
+	}
+
+	return nil
+}
+
+// bandTracker follows the rates of a history date by date and tells whether the rate of at least one currency
+// entered or left its band. The first date always counts as a change because it represents the version Check was
+// called with.
+type bandTracker struct {
+	bands    map[frankfurter.Currency]Band
+	previous map[frankfurter.Currency]bandState
+}
+
+func (t *bandTracker) changed(date frankfurter.YMD, rates frankfurter.Rates) (bool, error) {
+	current := make(map[frankfurter.Currency]bandState, len(t.bands))
+
+	for currency, band := range t.bands {
+		rate, found := rates[currency]
+
+		if !found {
+			return false, fmt.Errorf("currency %s is not available on %s", currency, date)
+		}
+
+		current[currency] = band.state(rate)
+	}
+
+	first := t.previous == nil
+	previous := t.previous
+	t.previous = current
+
+	return first || changed(previous, current), nil
+}
+
+func changed(previous, current map[frankfurter.Currency]bandState) bool {
+	for currency, state := range current {
+		if previous[currency] != state {
+			return true
+		}
+	}
+
+	return false
+}
+
+type bandObservation struct {
+	Date  frankfurter.YMD `json:"date"`
+	Rate  float32         `json:"rate"`
+	State bandState       `json:"state"`
+}
+
+// bandReport describes the position of a rate relative to its band, and whether it crossed a bound since the
+// previous publication.
+type bandReport struct {
+	Currency  frankfurter.Currency `json:"currency"`
+	Lower     *float32             `json:"lower,omitempty"`
+	Upper     *float32             `json:"upper,omitempty"`
+	Previous  *bandObservation     `json:"previous,omitempty"`
+	Current   bandObservation      `json:"current"`
+	Crossed   string               `json:"crossed,omitempty"`   // "lower" or "upper"
+	Direction string               `json:"direction,omitempty"` // "down" or "up"
+	Distance  *float64             `json:"distance,omitempty"`  // current rate minus the crossed bound
+}
+
+func newBandReport(currency frankfurter.Currency, band Band, current frankfurter.YMD, rate float32, previous *bandObservation) bandReport {
+	report := bandReport{
+		Currency: currency,
+		Lower:    band.Lower,
+		Upper:    band.Upper,
+		Previous: previous,
+		Current:  bandObservation{Date: current, Rate: rate, State: band.state(rate)},
+	}
+
+	if previous == nil || previous.State == report.Current.State {
+		return report
+	}
+
+	var bound float32
+
+	switch {
+	case report.Current.State == aboveBand:
+		report.Crossed, report.Direction, bound = "upper", "up", *band.Upper
+	case report.Current.State == belowBand:
+		report.Crossed, report.Direction, bound = "lower", "down", *band.Lower
+	case previous.State == aboveBand:
+		report.Crossed, report.Direction, bound = "upper", "down", *band.Upper
+	default:
+		report.Crossed, report.Direction, bound = "lower", "up", *band.Lower
+	}
+
+	distance := difference(rate, bound)
+	report.Distance = &distance
+
+	return report
+}
+
+func (r bandReport) String() string {
+	if r.Crossed == "" {
+		return string(r.Current.State)
+	}
+
+	return fmt.Sprintf("%s (crossed %s bound %sward by %s)", r.Current.State, r.Crossed, r.Direction, strconv.FormatFloat(*r.Distance, 'f', -1, 64))
+}
+
+// bandReports compares the given rates with those of the previous publication and describes, for each currency with
+// a band, whether a bound was crossed.
+func bandReports(ctx context.Context, service frankfurter.ExchangeRatesService, bands map[frankfurter.Currency]Band, rates *frankfurter.ExchangeRates) ([]bandReport, error) {
+	currencies := make([]frankfurter.Currency, 0, len(bands))
+
+	for currency := range bands {
+		currencies = append(currencies, currency)
+	}
+
+	slices.Sort(currencies)
+
+	preceding, err := adjacentRates(ctx, service, rates.Date, backward, currencies...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if preceding == nil {
+		preceding = &frankfurter.ExchangeRates{}
+	}
+
+	reports := make([]bandReport, 0, len(currencies))
+
+	for _, currency := range currencies {
+		band := bands[currency]
+		rate, found := rates.Rates[currency]
+
+		if !found {
+			return nil, fmt.Errorf("currency %s is not available", currency)
+		}
+
+		var previous *bandObservation
+
+		if previousRate, found := preceding.Rates[currency]; found {
+			previous = &bandObservation{Date: preceding.Date, Rate: previousRate, State: band.state(previousRate)}
+		}
+
+		reports = append(reports, newBandReport(currency, band, rates.Date, rate, previous))
+	}
+
+	return reports, nil
+}
+
+func writeBandReports(destination string, reports []bandReport) error {
+	directory := path.Join(destination, "bands")
+
+	err := os.MkdirAll(directory, 0755)
+
+	if err != nil {
+		return err
+	}
+
+	for _, report := range reports {
+		content, err := json.MarshalIndent(report, "", "  ")
+
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(path.Join(directory, string(report.Currency)+".json"), content, 0644)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package euroexchangerates_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/concourse-resource-go"
+	xr "github.com/suhlig/euro-exchange-rates-resource/euro-exchange-rates"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+func bound(f float32) *float32 {
+	return &f
+}
+
+var _ = Describe("Bands", func() {
+	var bands map[frankfurter.Currency]xr.Band
+
+	BeforeEach(func() {
+		bands = map[frankfurter.Currency]xr.Band{
+			frankfurter.Currency("USD"): {Lower: bound(1.05), Upper: bound(1.1)},
+		}
+	})
+
+	Describe("Check", func() {
+		var (
+			err      error
+			request  concourse.CheckRequest[xr.Source, xr.Version]
+			response concourse.CheckResponse[xr.Version]
+		)
+
+		BeforeEach(func() {
+			request = concourse.CheckRequest[xr.Source, xr.Version]{}
+			request.Source.URL = server.URL
+			request.Source.Bands = bands
+
+			start, e := frankfurter.NewYMD("2024-01-15")
+			Expect(e).ToNot(HaveOccurred())
+			request.Version = xr.Version{Date: start}
+
+			responseBody = `
+				{
+					"amount": 1.0,
+					"base": "EUR",
+					"start_date": "2024-01-15",
+					"end_date": "2024-01-19",
+					"rates": {
+						"2024-01-15": { "USD": 1.0887 },
+						"2024-01-16": { "USD": 1.1012 },
+						"2024-01-17": { "USD": 1.1034 },
+						"2024-01-18": { "USD": 1.0999 },
+						"2024-01-19": { "USD": 1.0872 }
+					}
+				}
+			`
+		})
+
+		JustBeforeEach(func(ctx SpecContext) {
+			response, err = resource.Check(ctx, request, GinkgoWriter)
+		})
+
+		It("works", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("only has the requested version and the dates on which the band was entered or left", func() {
+			Expect(response).To(HaveLen(3))
+			Expect(response[0].Date.String()).To(Equal("2024-01-15"))
+			Expect(response[1].Date.String()).To(Equal("2024-01-16"))
+			Expect(response[2].Date.String()).To(Equal("2024-01-18"))
+		})
+
+		Context("band with the lower bound above the upper one", func() {
+			BeforeEach(func() {
+				request.Source.Bands = map[frankfurter.Currency]xr.Band{
+					frankfurter.Currency("USD"): {Lower: bound(1.1), Upper: bound(1.05)},
+				}
+			})
+
+			It("fails", func() {
+				Expect(err).To(MatchError(ContainSubstring("above its upper bound")))
+			})
+		})
+
+		Context("band for a currency that is not configured", func() {
+			BeforeEach(func() {
+				request.Source.Currencies = []frankfurter.Currency{frankfurter.Currency("SEK")}
+			})
+
+			It("fails", func() {
+				Expect(err).To(MatchError(ContainSubstring("not among the configured currencies")))
+			})
+		})
+	})
+
+	Describe("Get", func() {
+		var (
+			err      error
+			request  concourse.GetRequest[xr.Source, xr.Version, xr.Params]
+			response *concourse.Response[xr.Version]
+		)
+
+		BeforeEach(func() {
+			request = concourse.GetRequest[xr.Source, xr.Version, xr.Params]{}
+			request.Source.URL = server.URL
+			request.Source.Bands = bands
+
+			date, e := frankfurter.NewYMD("2024-01-16")
+			Expect(e).ToNot(HaveOccurred())
+			request.Version = xr.Version{Date: date}
+
+			responses = map[string]string{
+				"/2024-01-16": `
+					{
+						"amount": 1.0,
+						"base": "EUR",
+						"date": "2024-01-16",
+						"rates": { "USD": 1.1012 }
+					}
+				`,
+				"/2024-01-11..2024-01-15": `
+					{
+						"amount": 1.0,
+						"base": "EUR",
+						"start_date": "2024-01-11",
+						"end_date": "2024-01-15",
+						"rates": {
+							"2024-01-12": { "USD": 1.0969 },
+							"2024-01-15": { "USD": 1.0887 }
+						}
+					}
+				`,
+			}
+		})
+
+		JustBeforeEach(func(ctx SpecContext) {
+			response, err = resource.Get(ctx, request, GinkgoWriter, inputDir)
+		})
+
+		It("works", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("writes the band report", func() {
+			content, err := os.ReadFile(filepath.Join(inputDir, "bands", "USD.json"))
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(content).To(MatchJSON(`
+				{
+					"currency": "USD",
+					"lower": 1.05,
+					"upper": 1.1,
+					"previous": { "date": "2024-01-15", "rate": 1.0887, "state": "within" },
+					"current": { "date": "2024-01-16", "rate": 1.1012, "state": "above" },
+					"crossed": "upper",
+					"direction": "up",
+					"distance": 0.0012
+				}
+			`))
+		})
+
+		It("describes the crossing in the metadata", func() {
+			Expect(response.Metadata).To(ContainElement(concourse.NameValuePair{
+				Name:  "USD band",
+				Value: "above (crossed upper bound upward by 0.0012)",
+			}))
+		})
+	})
+})
@@ -0,0 +1,85 @@
+package euroexchangerates
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+func validateCandles(p Params) error {
+	if len(p.Candles) > 0 && p.History == nil {
+		return errors.New("candles need a history window")
+	}
+
+	return nil
+}
+
+// writeCandles writes candles/<period>.csv and candles/<period>.json for each of the periods. The history must span
+// the window, not just the dates with publications, so that candles at its edges are marked as partial correctly.
+func writeCandles(destination string, history frankfurter.History, periods []frankfurter.Period) error {
+	directory := path.Join(destination, "candles")
+
+	err := os.MkdirAll(directory, 0755)
+
+	if err != nil {
+		return err
+	}
+
+	for _, period := range periods {
+		candles, err := history.Resample(period)
+
+		if err != nil {
+			return err
+		}
+
+		var buffer bytes.Buffer
+		w := csv.NewWriter(&buffer)
+		w.Write([]string{"start", "end", "currency", "open", "high", "low", "close", "observations", "partial"})
+
+		for _, c := range candles {
+			w.Write([]string{
+				c.Start.String(),
+				c.End.String(),
+				string(c.Currency),
+				rateString(c.Open),
+				rateString(c.High),
+				rateString(c.Low),
+				rateString(c.Close),
+				strconv.Itoa(c.Observations),
+				strconv.FormatBool(c.Partial),
+			})
+		}
+
+		w.Flush()
+
+		if w.Error() != nil {
+			return w.Error()
+		}
+
+		err = os.WriteFile(path.Join(directory, string(period)+".csv"), buffer.Bytes(), 0644)
+
+		if err != nil {
+			return err
+		}
+
+		content, err := json.MarshalIndent(candles, "", "  ")
+
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(path.Join(directory, string(period)+".json"), content, 0644)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
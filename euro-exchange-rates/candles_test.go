@@ -0,0 +1,98 @@
+package euroexchangerates_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/concourse-resource-go"
+	xr "github.com/suhlig/euro-exchange-rates-resource/euro-exchange-rates"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Candles", func() {
+	var (
+		err     error
+		request concourse.GetRequest[xr.Source, xr.Version, xr.Params]
+	)
+
+	BeforeEach(func() {
+		request = concourse.GetRequest[xr.Source, xr.Version, xr.Params]{}
+		request.Source.URL = server.URL
+		request.Source.Currencies = []frankfurter.Currency{frankfurter.Currency("USD")}
+		request.Params.History = &xr.Window{Days: 3}
+		request.Params.Candles = []frankfurter.Period{frankfurter.Weekly, frankfurter.Monthly}
+
+		date, e := frankfurter.NewYMD("2024-01-15")
+		Expect(e).ToNot(HaveOccurred())
+		request.Version = xr.Version{Date: date}
+
+		responses = map[string]string{
+			"/2024-01-15": `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "USD": 1.0882 } }`,
+			"/2024-01-11..2024-01-15": `
+				{
+					"amount": 1.0,
+					"base": "EUR",
+					"start_date": "2024-01-11",
+					"end_date": "2024-01-15",
+					"rates": {
+						"2024-01-11": { "USD": 1.0987 },
+						"2024-01-12": { "USD": 1.0942 },
+						"2024-01-15": { "USD": 1.0882 }
+					}
+				}
+			`,
+		}
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		_, err = resource.Get(ctx, request, GinkgoWriter, inputDir)
+	})
+
+	It("works", func() {
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("writes weekly candles as CSV", func() {
+		Expect(file("candles/weekly.csv")).To(Equal(`start,end,currency,open,high,low,close,observations,partial
+2024-01-08,2024-01-14,USD,1.0987,1.0987,1.0942,1.0942,2,true
+2024-01-15,2024-01-21,USD,1.0882,1.0882,1.0882,1.0882,1,true
+`))
+	})
+
+	It("writes monthly candles as JSON", func() {
+		Expect(file("candles/monthly.json")).To(MatchJSON(`
+			[
+				{
+					"currency": "USD",
+					"start": "2024-01-01",
+					"end": "2024-01-31",
+					"open": 1.0987,
+					"high": 1.0987,
+					"low": 1.0882,
+					"close": 1.0882,
+					"observations": 3,
+					"partial": true
+				}
+			]
+		`))
+	})
+
+	Context("without a history window", func() {
+		BeforeEach(func() {
+			request.Params.History = nil
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring("candles need a history window")))
+		})
+	})
+
+	Context("unknown period", func() {
+		BeforeEach(func() {
+			request.Params.Candles = []frankfurter.Period{"hourly"}
+		})
+
+		It("is rejected by the request validation", func() {
+			Expect(request.Validate()).To(MatchError(ContainSubstring("Candles[0]")))
+		})
+	})
+})
@@ -47,7 +47,7 @@ var _ = Describe("Check", func() {
 		})
 
 		It("has the expected request query", func() {
-			Expect(requestURL.Query().Get("to")).To(Equal("SEK,USD"))
+			Expect(requestURL.Query().Get("to")).To(Equal("SEK"))
 		})
 
 		It("has exactly one version", func() {
@@ -104,7 +104,17 @@ var _ = Describe("Check", func() {
 			})
 
 			It("has the expected request query", func() {
-				Expect(requestURL.Query().Get("to")).To(Equal("FOO,BAR"))
+				Expect(requestURL.Query().Get("to")).To(Equal("FOO"))
+			})
+		})
+
+		Context("no currencies configured", func() {
+			BeforeEach(func() {
+				request.Source.Currencies = nil
+			})
+
+			It("requests a single currency only", func() {
+				Expect(requestURL.Query().Get("to")).To(Equal("USD"))
 			})
 		})
 
@@ -153,6 +163,71 @@ var _ = Describe("Check", func() {
 		})
 	})
 
+	Context("version long ago given", func() {
+		BeforeEach(func() {
+			request.Source.URL = server.URL
+
+			newYear, e := frankfurter.NewYMD("2024-01-02")
+			Expect(e).ToNot(HaveOccurred())
+			request.Version = xr.Version{Date: newYear}
+
+			responses = map[string]string{
+				"/2024-01-02..": `
+					{
+						"amount": 1.0,
+						"base": "EUR",
+						"start_date": "2024-01-02",
+						"end_date": "2024-01-16",
+						"rates": {
+							"2024-01-02": { "USD": 1.0956 },
+							"2024-01-09": { "USD": 1.0940 },
+							"2024-01-16": { "USD": 1.0882 }
+						}
+					}
+				`,
+				"/2024-01-02..2024-01-09": `
+					{
+						"amount": 1.0,
+						"base": "EUR",
+						"start_date": "2024-01-02",
+						"end_date": "2024-01-09",
+						"rates": {
+							"2024-01-02": { "USD": 1.0956 },
+							"2024-01-03": { "USD": 1.0919 },
+							"2024-01-04": { "USD": 1.0953 },
+							"2024-01-05": { "USD": 1.0921 },
+							"2024-01-08": { "USD": 1.0946 },
+							"2024-01-09": { "USD": 1.094 }
+						}
+					}
+				`,
+				"/2024-01-10..": `
+					{
+						"amount": 1.0,
+						"base": "EUR",
+						"start_date": "2024-01-10",
+						"end_date": "2024-01-16",
+						"rates": {
+							"2024-01-10": { "USD": 1.0946 },
+							"2024-01-11": { "USD": 1.0977 },
+							"2024-01-12": { "USD": 1.0969 },
+							"2024-01-15": { "USD": 1.0887 },
+							"2024-01-16": { "USD": 1.0882 }
+						}
+					}
+				`,
+			}
+		})
+
+		It("works", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("has a version for every day despite the API downsampling the range", func() {
+			Expect(response).To(HaveLen(11))
+		})
+	})
+
 	Context("empty source config", func() {
 		It("fails", func() {
 			Expect(err).To(HaveOccurred())
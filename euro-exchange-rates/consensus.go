@@ -0,0 +1,141 @@
+package euroexchangerates
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+// Consensus configures a second, independent source that Get compares the rates with
+type Consensus struct {
+	URL string `json:"url" validate:"required,http_url"`
+
+	// Tolerance is the largest relative difference (in percent) between the two sources that is still accepted
+	Tolerance float64 `json:"tolerance" validate:"gte=0"`
+}
+
+type reconciliation struct {
+	Date       frankfurter.YMD      `json:"date"`
+	Primary    string               `json:"primary"`
+	Secondary  string               `json:"secondary"`
+	Tolerance  float64              `json:"tolerance"`
+	Currencies []reconciledCurrency `json:"currencies"`
+}
+
+type reconciledCurrency struct {
+	Currency   frankfurter.Currency `json:"currency"`
+	Primary    *float32             `json:"primary"`
+	Secondary  *float32             `json:"secondary"`
+	Difference *float64             `json:"difference,omitempty"` // secondary minus primary
+	Relative   *float64             `json:"relative,omitempty"`   // difference in percent of primary
+	Agrees     bool                 `json:"agrees"`
+}
+
+// reconcile fetches the rates from the second source configured for consensus and compares them with the given ones.
+func (r ConcourseResource[S, V, P]) reconcile(ctx context.Context, log io.Writer, source Source, rates *frankfurter.ExchangeRates) (*reconciliation, error) {
+	fmt.Fprintf(log, "Reconciling rates as of %s with %s\n", rates.Date, source.Consensus.URL)
+
+	secondary, err := frankfurter.ExchangeRatesService{URL: source.Consensus.URL, HttpClient: r.HttpClient}.At(ctx, rates.Date, source.Currencies...)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch rates as of %s from %s for reconciliation: %w", rates.Date, source.Consensus.URL, err)
+	}
+
+	if !secondary.Date.Equal(rates.Date) {
+		return nil, fmt.Errorf("%s has no rates as of %s; closest is %s", source.Consensus.URL, rates.Date, secondary.Date)
+	}
+
+	currencies := source.Currencies
+
+	if len(currencies) == 0 {
+		for currency := range rates.Rates {
+			currencies = append(currencies, currency)
+		}
+	}
+
+	currencies = slices.Clone(currencies)
+	slices.Sort(currencies)
+
+	result := &reconciliation{
+		Date:      rates.Date,
+		Primary:   source.endpoint(),
+		Secondary: source.Consensus.URL,
+		Tolerance: source.Consensus.Tolerance,
+	}
+
+	for _, currency := range currencies {
+		result.Currencies = append(result.Currencies, reconcileCurrency(currency, rates.Rates, secondary.Rates, source.Consensus.Tolerance))
+	}
+
+	return result, nil
+}
+
+func reconcileCurrency(currency frankfurter.Currency, primary, secondary frankfurter.Rates, tolerance float64) reconciledCurrency {
+	reconciled := reconciledCurrency{Currency: currency}
+
+	if rate, found := primary[currency]; found {
+		reconciled.Primary = &rate
+	}
+
+	if rate, found := secondary[currency]; found {
+		reconciled.Secondary = &rate
+	}
+
+	if reconciled.Primary == nil || reconciled.Secondary == nil {
+		return reconciled
+	}
+
+	difference := difference(*reconciled.Secondary, *reconciled.Primary)
+	relative := math.Round(difference/float64(*reconciled.Primary)*100*1e6) / 1e6
+
+	reconciled.Difference = &difference
+	reconciled.Relative = &relative
+	reconciled.Agrees = math.Abs(relative) <= tolerance
+
+	return reconciled
+}
+
+// disagreement returns an error naming the currencies and sources that do not agree, or nil if all of them do.
+func (r reconciliation) disagreement() error {
+	var disagreeing []string
+
+	for _, c := range r.Currencies {
+		if !c.Agrees {
+			disagreeing = append(disagreeing, fmt.Sprintf("%s (%s: %s, %s: %s)", c.Currency, r.Primary, optionalRate(c.Primary), r.Secondary, optionalRate(c.Secondary)))
+		}
+	}
+
+	if len(disagreeing) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("sources disagree on the rates as of %s beyond the tolerance of %s%%: %s",
+		r.Date, strconv.FormatFloat(r.Tolerance, 'f', -1, 64), strings.Join(disagreeing, ", "))
+}
+
+func optionalRate(rate *float32) string {
+	if rate == nil {
+		return "no rate"
+	}
+
+	return rateString(*rate)
+}
+
+func writeReconciliation(destination string, r *reconciliation) error {
+	content, err := json.MarshalIndent(r, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path.Join(destination, "reconciliation.json"), content, 0644)
+}
@@ -0,0 +1,106 @@
+package euroexchangerates_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/concourse-resource-go"
+	xr "github.com/suhlig/euro-exchange-rates-resource/euro-exchange-rates"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Consensus", func() {
+	var (
+		err          error
+		request      concourse.GetRequest[xr.Source, xr.Version, xr.Params]
+		secondary    *httptest.Server
+		secondaryUSD string
+	)
+
+	BeforeEach(func() {
+		secondaryUSD = "1.0883"
+
+		secondary = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "SEK": 11.3215, "USD": %s } }`, secondaryUSD)
+		}))
+
+		request = concourse.GetRequest[xr.Source, xr.Version, xr.Params]{}
+		request.Source.URL = server.URL
+		request.Source.Currencies = []frankfurter.Currency{frankfurter.Currency("USD"), frankfurter.Currency("SEK")}
+		request.Source.Consensus = &xr.Consensus{URL: secondary.URL, Tolerance: 0.01}
+
+		date, e := frankfurter.NewYMD("2024-01-15")
+		Expect(e).ToNot(HaveOccurred())
+		request.Version = xr.Version{Date: date}
+
+		responseBody = `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "SEK": 11.3215, "USD": 1.0882 } }`
+	})
+
+	AfterEach(func() {
+		secondary.Close()
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		_, err = resource.Get(ctx, request, GinkgoWriter, inputDir)
+	})
+
+	It("works if the sources agree within the tolerance", func() {
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("writes the reconciliation report", func() {
+		content, err := os.ReadFile(filepath.Join(inputDir, "reconciliation.json"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(content).To(MatchJSON(fmt.Sprintf(`
+			{
+				"date": "2024-01-15",
+				"primary": "%s",
+				"secondary": "%s",
+				"tolerance": 0.01,
+				"currencies": [
+					{ "currency": "SEK", "primary": 11.3215, "secondary": 11.3215, "difference": 0, "relative": 0, "agrees": true },
+					{ "currency": "USD", "primary": 1.0882, "secondary": 1.0883, "difference": 0.0001, "relative": 0.009189, "agrees": true }
+				]
+			}
+		`, server.URL, secondary.URL)))
+	})
+
+	Context("sources disagree", func() {
+		BeforeEach(func() {
+			secondaryUSD = "1.09"
+		})
+
+		It("names the currency and the sources", func() {
+			Expect(err).To(MatchError(ContainSubstring("USD (%s: 1.0882, %s: 1.09)", server.URL, secondary.URL)))
+		})
+
+		It("does not complain about the agreeing currency", func() {
+			Expect(err).ToNot(MatchError(ContainSubstring("SEK")))
+		})
+	})
+	Context("a consensus without URL", func() {
+		BeforeEach(func() {
+			request.Source.Consensus.URL = ""
+		})
+
+		It("is rejected by the request validation", func() {
+			Expect(request.Validate()).To(MatchError(ContainSubstring("Consensus.URL")))
+		})
+	})
+
+	Context("a negative tolerance", func() {
+		BeforeEach(func() {
+			request.Source.Consensus.Tolerance = -1
+		})
+
+		It("is rejected by the request validation", func() {
+			Expect(request.Validate()).To(MatchError(ContainSubstring("Consensus.Tolerance")))
+		})
+	})
+})
@@ -0,0 +1,161 @@
+package euroexchangerates
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/suhlig/euro-exchange-rates-resource/currency"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+// Convert lists amounts that Get converts into every currency
+type Convert struct {
+	Amounts  []float64            `json:"amounts" validate:"required,dive,gt=0"`
+	From     frankfurter.Currency `json:"from"`                                                          // defaults to the base
+	Rounding string               `json:"rounding" validate:"omitempty,oneof=half-up half-even down up"` // defaults to half-up
+}
+
+func (c Convert) rounding() string {
+	if c.Rounding == "" {
+		return currency.HalfUp.String()
+	}
+
+	return c.Rounding
+}
+
+// iso returns the ISO 4217 details of the currency. Unknown currencies are assumed to have two decimals.
+func iso(c frankfurter.Currency) currency.Currency {
+	details, found := currency.Lookup(string(c))
+
+	if !found {
+		return currency.Currency{Code: string(c), MinorUnits: 2}
+	}
+
+	return details
+}
+
+type conversion struct {
+	Amount    float64              `json:"amount"`
+	Currency  frankfurter.Currency `json:"currency"`
+	Value     float64              `json:"value"`
+	formatted string               // with exactly as many decimals as the currency has minor units
+	exact     struct{ amount, value *big.Rat }
+}
+
+type conversionTable struct {
+	Date        frankfurter.YMD      `json:"date"`
+	From        frankfurter.Currency `json:"from"`
+	Rounding    string               `json:"rounding"`
+	Conversions []conversion         `json:"conversions"`
+}
+
+// newConversionTable converts each amount into every other currency, rounding the values to the minor units of the
+// currency. The calculation is exact; only the rounding changes the value.
+func newConversionTable(rates *frankfurter.ExchangeRates, convert Convert) (conversionTable, error) {
+	from := convert.From
+
+	if from == "" {
+		from = rates.Base
+	}
+
+	mode, err := currency.ParseRoundingMode(convert.rounding())
+
+	if err != nil {
+		return conversionTable{}, err
+	}
+
+	perBase := exactUnitRates(rates)
+	rate, found := perBase[from]
+
+	if !found {
+		return conversionTable{}, fmt.Errorf("cannot convert from %s, as it is not among the currencies", from)
+	}
+
+	table := conversionTable{Date: rates.Date, From: from, Rounding: mode.String()}
+	currencies := append([]frankfurter.Currency{rates.Base}, sortedCurrencies(rates.Rates)...)
+
+	for _, amount := range convert.Amounts {
+		exactAmount, _ := new(big.Rat).SetString(strconv.FormatFloat(amount, 'f', -1, 64))
+
+		for _, c := range currencies {
+			if c == from {
+				continue
+			}
+
+			exact := new(big.Rat).Mul(exactAmount, new(big.Rat).Quo(perBase[c], rate))
+			rounded := iso(c).Round(exact, mode)
+			value, _ := rounded.Float64()
+
+			converted := conversion{
+				Amount:    amount,
+				Currency:  c,
+				Value:     value,
+				formatted: rounded.FloatString(iso(c).MinorUnits),
+			}
+
+			converted.exact.amount, converted.exact.value = exactAmount, rounded
+			table.Conversions = append(table.Conversions, converted)
+		}
+	}
+
+	return table, nil
+}
+
+// exactUnitRates returns the units of each currency, including the base, that one unit of the base buys
+func exactUnitRates(rates *frankfurter.ExchangeRates) map[frankfurter.Currency]*big.Rat {
+	amount := big.NewRat(1, 1)
+
+	if rates.Amount != 0 {
+		amount.SetString(rateString(rates.Amount))
+	}
+
+	perBase := map[frankfurter.Currency]*big.Rat{rates.Base: big.NewRat(1, 1)}
+
+	for c, rate := range rates.Rates {
+		exact, _ := new(big.Rat).SetString(rateString(rate))
+		perBase[c] = exact.Quo(exact, amount)
+	}
+
+	return perBase
+}
+
+func writeConversions(destination string, table conversionTable) error {
+	var buffer bytes.Buffer
+	w := csv.NewWriter(&buffer)
+	w.Write([]string{"amount", "from", "currency", "value"})
+
+	for _, c := range table.Conversions {
+		w.Write([]string{
+			strconv.FormatFloat(c.Amount, 'f', -1, 64),
+			string(table.From),
+			string(c.Currency),
+			c.formatted,
+		})
+	}
+
+	w.Flush()
+
+	if w.Error() != nil {
+		return w.Error()
+	}
+
+	err := os.WriteFile(path.Join(destination, "conversions.csv"), buffer.Bytes(), 0644)
+
+	if err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(table, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path.Join(destination, "conversions.json"), content, 0644)
+}
@@ -0,0 +1,131 @@
+package euroexchangerates_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/concourse-resource-go"
+	xr "github.com/suhlig/euro-exchange-rates-resource/euro-exchange-rates"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Conversion", func() {
+	var (
+		err     error
+		request concourse.GetRequest[xr.Source, xr.Version, xr.Params]
+	)
+
+	BeforeEach(func() {
+		request = concourse.GetRequest[xr.Source, xr.Version, xr.Params]{}
+		request.Source.URL = server.URL
+		request.Source.Currencies = []frankfurter.Currency{frankfurter.Currency("USD"), frankfurter.Currency("SEK"), frankfurter.Currency("JPY")}
+		request.Params.Convert = &xr.Convert{Amounts: []float64{9.99, 49}}
+
+		date, e := frankfurter.NewYMD("2024-01-15")
+		Expect(e).ToNot(HaveOccurred())
+		request.Version = xr.Version{Date: date}
+
+		responseBody = `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "JPY": 160.52, "SEK": 11.3215, "USD": 1.0882 } }`
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		_, err = resource.Get(ctx, request, GinkgoWriter, inputDir)
+	})
+
+	It("works", func() {
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("writes a CSV table rounded to the minor units of each currency", func() {
+		Expect(file("conversions.csv")).To(Equal(`amount,from,currency,value
+9.99,EUR,JPY,1604
+9.99,EUR,SEK,113.10
+9.99,EUR,USD,10.87
+49,EUR,JPY,7865
+49,EUR,SEK,554.75
+49,EUR,USD,53.32
+`))
+	})
+
+	It("writes a JSON table", func() {
+		Expect(file("conversions.json")).To(MatchJSON(`
+			{
+				"date": "2024-01-15",
+				"from": "EUR",
+				"rounding": "half-up",
+				"conversions": [
+					{ "amount": 9.99, "currency": "JPY", "value": 1604 },
+					{ "amount": 9.99, "currency": "SEK", "value": 113.1 },
+					{ "amount": 9.99, "currency": "USD", "value": 10.87 },
+					{ "amount": 49, "currency": "JPY", "value": 7865 },
+					{ "amount": 49, "currency": "SEK", "value": 554.75 },
+					{ "amount": 49, "currency": "USD", "value": 53.32 }
+				]
+			}
+		`))
+	})
+
+	Context("from another currency", func() {
+		BeforeEach(func() {
+			request.Params.Convert = &xr.Convert{Amounts: []float64{100}, From: frankfurter.Currency("USD")}
+		})
+
+		It("converts via the base", func() {
+			Expect(file("conversions.csv")).To(Equal(`amount,from,currency,value
+100,USD,EUR,91.89
+100,USD,JPY,14751
+100,USD,SEK,1040.39
+`))
+		})
+	})
+
+	DescribeTable("rounding a tie",
+		func(rounding, expected string) {
+			// 1.085 USD is halfway between two cents
+			responseBody = `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "USD": 1.085 } }`
+			request.Source.Currencies = []frankfurter.Currency{frankfurter.Currency("USD")}
+			request.Params.Convert = &xr.Convert{Amounts: []float64{1}, Rounding: rounding}
+
+			_, err := resource.Get(context.Background(), request, GinkgoWriter, inputDir)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(file("conversions.csv")).To(HaveSuffix("1,EUR,USD," + expected + "\n"))
+		},
+		Entry("half-up by default", "", "1.09"),
+		Entry("half-up", "half-up", "1.09"),
+		Entry("half-even", "half-even", "1.08"),
+		Entry("down", "down", "1.08"),
+		Entry("up", "up", "1.09"),
+	)
+
+	Context("an unknown rounding mode", func() {
+		BeforeEach(func() {
+			request.Params.Convert.Rounding = "ceiling"
+		})
+
+		It("is rejected by the request validation", func() {
+			Expect(request.Validate()).To(MatchError(ContainSubstring("Convert.Rounding")))
+		})
+	})
+
+	Context("from a currency that is not available", func() {
+		BeforeEach(func() {
+			request.Params.Convert = &xr.Convert{Amounts: []float64{100}, From: frankfurter.Currency("GBP")}
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring("cannot convert from GBP")))
+		})
+	})
+
+	Context("no amounts", func() {
+		BeforeEach(func() {
+			request.Params.Convert = &xr.Convert{}
+		})
+
+		It("is rejected by the request validation", func() {
+			Expect(request.Validate()).To(MatchError(ContainSubstring("Convert.Amounts")))
+		})
+	})
+})
@@ -0,0 +1,161 @@
+package euroexchangerates
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path"
+	"strconv"
+
+	"github.com/suhlig/euro-exchange-rates-resource/currency"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+// Cross configures the rates that Get derives from the published ones
+type Cross struct {
+	Inverse  bool   `json:"inverse"`                                                       // write inverse/<currency>, the base per unit of the currency
+	Matrix   bool   `json:"matrix"`                                                        // write cross/<from>/<to>, cross.csv and cross.json
+	Decimals *int   `json:"decimals" validate:"omitempty,gte=0,lte=10"`                    // defaults to 6
+	Rounding string `json:"rounding" validate:"omitempty,oneof=half-up half-even down up"` // defaults to half-up
+}
+
+const defaultCrossDecimals = 6
+
+func (c Cross) decimals() int {
+	if c.Decimals == nil {
+		return defaultCrossDecimals
+	}
+
+	return *c.Decimals
+}
+
+func (c Cross) rounding() string {
+	if c.Rounding == "" {
+		return currency.HalfUp.String()
+	}
+
+	return c.Rounding
+}
+
+// crossRates are the rates between every pair of currencies, including the base. The base comes first in Currencies.
+type crossRates struct {
+	Date       frankfurter.YMD                                           `json:"date"`
+	Decimals   int                                                       `json:"decimals"`
+	Rounding   string                                                    `json:"rounding"`
+	Currencies []frankfurter.Currency                                    `json:"-"`
+	Rates      map[frankfurter.Currency]map[frankfurter.Currency]float64 `json:"rates"` // units of the inner currency per unit of the outer
+}
+
+// newCrossRates derives the cross rates from the given ones. As the published rates have no more than six significant
+// digits anyway, the derived rates are calculated exactly and rounded to the configured number of decimals.
+func newCrossRates(rates *frankfurter.ExchangeRates, config Cross) (crossRates, error) {
+	mode, err := currency.ParseRoundingMode(config.rounding())
+
+	if err != nil {
+		return crossRates{}, err
+	}
+
+	perBase := exactUnitRates(rates)
+
+	cross := crossRates{
+		Date:       rates.Date,
+		Decimals:   config.decimals(),
+		Rounding:   mode.String(),
+		Currencies: append([]frankfurter.Currency{rates.Base}, sortedCurrencies(rates.Rates)...),
+		Rates:      make(map[frankfurter.Currency]map[frankfurter.Currency]float64, len(perBase)),
+	}
+
+	for from, f := range perBase {
+		cross.Rates[from] = make(map[frankfurter.Currency]float64, len(perBase))
+
+		for to, t := range perBase {
+			cross.Rates[from][to], _ = currency.Round(new(big.Rat).Quo(t, f), cross.Decimals, mode).Float64()
+		}
+	}
+
+	return cross, nil
+}
+
+// writeInverse writes inverse/<currency> with the amount of the base that one unit of the currency buys
+func writeInverse(destination string, cross crossRates) error {
+	directory := path.Join(destination, "inverse")
+
+	err := os.MkdirAll(directory, 0755)
+
+	if err != nil {
+		return err
+	}
+
+	base := cross.Currencies[0]
+
+	for _, currency := range cross.Currencies[1:] {
+		err = os.WriteFile(path.Join(directory, string(currency)), []byte(crossString(cross.Rates[currency][base])), 0644)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeMatrix writes a file cross/<from>/<to> per pair of currencies, and the whole matrix as cross.csv and cross.json
+func writeMatrix(destination string, cross crossRates) error {
+	for _, from := range cross.Currencies {
+		directory := path.Join(destination, "cross", string(from))
+
+		err := os.MkdirAll(directory, 0755)
+
+		if err != nil {
+			return err
+		}
+
+		for _, to := range cross.Currencies {
+			err = os.WriteFile(path.Join(directory, string(to)), []byte(crossString(cross.Rates[from][to])), 0644)
+
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	var buffer bytes.Buffer
+	w := csv.NewWriter(&buffer)
+	w.Write(append([]string{"from"}, currencyCodes(cross.Currencies)...))
+
+	for _, from := range cross.Currencies {
+		row := []string{string(from)}
+
+		for _, to := range cross.Currencies {
+			row = append(row, crossString(cross.Rates[from][to]))
+		}
+
+		w.Write(row)
+	}
+
+	w.Flush()
+
+	if w.Error() != nil {
+		return w.Error()
+	}
+
+	err := os.WriteFile(path.Join(destination, "cross.csv"), buffer.Bytes(), 0644)
+
+	if err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(cross, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path.Join(destination, "cross.json"), content, 0644)
+}
+
+func crossString(rate float64) string {
+	return strconv.FormatFloat(rate, 'f', -1, 64)
+}
@@ -0,0 +1,150 @@
+package euroexchangerates_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/concourse-resource-go"
+	xr "github.com/suhlig/euro-exchange-rates-resource/euro-exchange-rates"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Cross rates", func() {
+	var (
+		err     error
+		request concourse.GetRequest[xr.Source, xr.Version, xr.Params]
+	)
+
+	BeforeEach(func() {
+		request = concourse.GetRequest[xr.Source, xr.Version, xr.Params]{}
+		request.Source.URL = server.URL
+		request.Source.Currencies = []frankfurter.Currency{frankfurter.Currency("USD"), frankfurter.Currency("SEK")}
+		request.Params.Cross = &xr.Cross{}
+
+		date, e := frankfurter.NewYMD("2024-01-15")
+		Expect(e).ToNot(HaveOccurred())
+		request.Version = xr.Version{Date: date}
+
+		responseBody = `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "USD": 1.0882, "SEK": 11.3215 } }`
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		_, err = resource.Get(ctx, request, GinkgoWriter, inputDir)
+	})
+
+	Context("neither inverse nor matrix", func() {
+		It("writes nothing extra", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filepath.Join(inputDir, "inverse")).ToNot(BeAnExistingFile())
+			Expect(filepath.Join(inputDir, "cross")).ToNot(BeAnExistingFile())
+		})
+	})
+
+	Context("inverse", func() {
+		BeforeEach(func() {
+			request.Params.Cross.Inverse = true
+		})
+
+		It("writes the base per unit of each currency", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file("inverse/USD")).To(Equal("0.918949"))
+			Expect(file("inverse/SEK")).To(Equal("0.088328"))
+		})
+	})
+
+	Context("matrix", func() {
+		BeforeEach(func() {
+			request.Params.Cross.Matrix = true
+		})
+
+		It("writes a file per pair", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file("cross/USD/SEK")).To(Equal("10.403878"))
+			Expect(file("cross/SEK/USD")).To(Equal("0.096118"))
+			Expect(file("cross/EUR/USD")).To(Equal("1.0882"))
+			Expect(file("cross/USD/USD")).To(Equal("1"))
+		})
+
+		It("writes the matrix as CSV with the base first", func() {
+			Expect(file("cross.csv")).To(Equal(`from,EUR,SEK,USD
+EUR,1,11.3215,1.0882
+SEK,0.088328,1,0.096118
+USD,0.918949,10.403878,1
+`))
+		})
+
+		It("writes the matrix as JSON", func() {
+			Expect(file("cross.json")).To(MatchJSON(`
+				{
+					"date": "2024-01-15",
+					"decimals": 6,
+					"rounding": "half-up",
+					"rates": {
+						"EUR": { "EUR": 1, "SEK": 11.3215, "USD": 1.0882 },
+						"SEK": { "EUR": 0.088328, "SEK": 1, "USD": 0.096118 },
+						"USD": { "EUR": 0.918949, "SEK": 10.403878, "USD": 1 }
+					}
+				}
+			`))
+		})
+
+		Context("rounded to two decimals", func() {
+			BeforeEach(func() {
+				decimals := 2
+				request.Params.Cross.Decimals = &decimals
+			})
+
+			It("rounds", func() {
+				Expect(file("cross/USD/SEK")).To(Equal("10.4"))
+				Expect(file("cross/SEK/USD")).To(Equal("0.1"))
+			})
+
+			Context("down", func() {
+				BeforeEach(func() {
+					request.Params.Cross.Rounding = "down"
+				})
+
+				It("truncates", func() {
+					Expect(file("cross/USD/SEK")).To(Equal("10.4"))
+					Expect(file("cross/SEK/USD")).To(Equal("0.09"))
+				})
+			})
+		})
+
+		Context("based on an amount", func() {
+			BeforeEach(func() {
+				request.Params.Amount = 100
+				responses = map[string]string{
+					"/2024-01-15?amount=100&to=USD%2CSEK": `{ "amount": 100.0, "base": "EUR", "date": "2024-01-15", "rates": { "USD": 108.82, "SEK": 1132.15 } }`,
+				}
+			})
+
+			It("derives the rates per unit", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(file("cross/USD/SEK")).To(Equal("10.403878"))
+			})
+		})
+	})
+
+	Context("too many decimals", func() {
+		BeforeEach(func() {
+			decimals := 11
+			request.Params.Cross.Decimals = &decimals
+		})
+
+		It("is rejected by the request validation", func() {
+			Expect(request.Validate()).To(MatchError(ContainSubstring("Cross.Decimals")))
+		})
+	})
+
+	Context("unknown rounding", func() {
+		BeforeEach(func() {
+			request.Params.Cross.Rounding = "sideways"
+		})
+
+		It("is rejected by the request validation", func() {
+			Expect(request.Validate()).To(MatchError(ContainSubstring("Cross.Rounding")))
+		})
+	})
+})
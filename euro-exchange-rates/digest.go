@@ -0,0 +1,35 @@
+package euroexchangerates
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+// digest hashes the canonical form of the rates, which is one line "<currency>=<rate>" per currency, sorted by
+// currency. If currencies are given, only those are taken into account; otherwise all of the passed rates are.
+func digest(rates frankfurter.Rates, currencies []frankfurter.Currency) string {
+	if len(currencies) == 0 {
+		for currency := range rates {
+			currencies = append(currencies, currency)
+		}
+	} else {
+		currencies = slices.Clone(currencies)
+	}
+
+	slices.Sort(currencies)
+
+	var canonical strings.Builder
+
+	for _, currency := range slices.Compact(currencies) {
+		fmt.Fprintf(&canonical, "%s=%s\n", currency, rateString(rates[currency]))
+	}
+
+	sum := sha256.Sum256([]byte(canonical.String()))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,159 @@
+package euroexchangerates_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/concourse-resource-go"
+	xr "github.com/suhlig/euro-exchange-rates-resource/euro-exchange-rates"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+func sha256Hex(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+var _ = Describe("Digest", func() {
+	Describe("Check", func() {
+		var (
+			err      error
+			request  concourse.CheckRequest[xr.Source, xr.Version]
+			response concourse.CheckResponse[xr.Version]
+		)
+
+		BeforeEach(func() {
+			request = concourse.CheckRequest[xr.Source, xr.Version]{}
+			request.Source.URL = server.URL
+			request.Source.Digest = true
+			request.Source.Currencies = []frankfurter.Currency{
+				frankfurter.Currency("USD"),
+				frankfurter.Currency("SEK"),
+			}
+
+			responseBody = `
+				{
+					"amount": 1.0,
+					"base": "EUR",
+					"start_date": "2024-01-15",
+					"end_date": "2024-01-16",
+					"rates": {
+						"2024-01-15": { "SEK": 11.3215, "USD": 1.0887 },
+						"2024-01-16": { "SEK": 11.3300, "USD": 1.089 }
+					}
+				}
+			`
+		})
+
+		JustBeforeEach(func(ctx SpecContext) {
+			response, err = resource.Check(ctx, request, GinkgoWriter)
+		})
+
+		Context("no version given", func() {
+			BeforeEach(func() {
+				responseBody = `
+					{
+						"amount": 1.0,
+						"base": "EUR",
+						"date": "2024-01-16",
+						"rates": { "SEK": 11.33, "USD": 1.089 }
+					}
+				`
+			})
+
+			It("has the digest of the canonicalised rates", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).To(HaveLen(1))
+				Expect(response[0].Digest).To(Equal(sha256Hex("SEK=11.33\nUSD=1.089\n")))
+			})
+		})
+
+		Context("version with the same digest given", func() {
+			BeforeEach(func() {
+				date, e := frankfurter.NewYMD("2024-01-15")
+				Expect(e).ToNot(HaveOccurred())
+				request.Version = xr.Version{Date: date, Digest: sha256Hex("SEK=11.3215\nUSD=1.0887\n")}
+			})
+
+			It("requests all configured currencies", func() {
+				Expect(requestURL.Query().Get("to")).To(Equal("USD,SEK"))
+			})
+
+			It("starts with the requested version", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).To(HaveLen(2))
+				Expect(response[0]).To(Equal(request.Version))
+			})
+		})
+
+		Context("version with a different digest given", func() {
+			BeforeEach(func() {
+				date, e := frankfurter.NewYMD("2024-01-15")
+				Expect(e).ToNot(HaveOccurred())
+				request.Version = xr.Version{Date: date, Digest: sha256Hex("SEK=11.3215\nUSD=1.0\n")}
+			})
+
+			It("emits a new version for the revised date", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response[0].Date.String()).To(Equal("2024-01-15"))
+				Expect(response[0].Digest).ToNot(Equal(request.Version.Digest))
+			})
+		})
+	})
+
+	Describe("Get", func() {
+		var (
+			err      error
+			request  concourse.GetRequest[xr.Source, xr.Version, xr.Params]
+			response *concourse.Response[xr.Version]
+		)
+
+		BeforeEach(func() {
+			request = concourse.GetRequest[xr.Source, xr.Version, xr.Params]{}
+			request.Source.URL = server.URL
+
+			date, e := frankfurter.NewYMD("2024-01-15")
+			Expect(e).ToNot(HaveOccurred())
+			request.Version = xr.Version{Date: date}
+
+			responseBody = `
+				{
+					"amount": 1.0,
+					"base": "EUR",
+					"date": "2024-01-15",
+					"rates": { "SEK": 11.3215, "USD": 1.0882 }
+				}
+			`
+		})
+
+		JustBeforeEach(func(ctx SpecContext) {
+			response, err = resource.Get(ctx, request, GinkgoWriter, GinkgoT().TempDir())
+		})
+
+		Context("matching digest", func() {
+			BeforeEach(func() {
+				request.Version.Digest = sha256Hex("SEK=11.3215\nUSD=1.0882\n")
+			})
+
+			It("works", func() {
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("responds with the requested version", func() {
+				Expect(response.Version).To(Equal(request.Version))
+			})
+		})
+
+		Context("mismatching digest", func() {
+			BeforeEach(func() {
+				request.Version.Digest = sha256Hex("SEK=11.3215\nUSD=1.0\n")
+			})
+
+			It("refuses to deliver the data", func() {
+				Expect(err).To(MatchError(ContainSubstring("do not match the requested version")))
+			})
+		})
+	})
+})
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -22,14 +24,33 @@ var (
 	server       *httptest.Server
 	resource     concourse.Resource[xr.Source, xr.Version, xr.Params]
 	responseBody string
+	responses    map[string]string // by request path, with or without query; takes precedence over responseBody
 	requestURL   *url.URL
+	inputDir     string // the destination of Get; a new temporary directory for every spec
 )
 
+// file returns the content of the named file in inputDir
+func file(name string) string {
+	content, err := os.ReadFile(filepath.Join(inputDir, name))
+	Expect(err).ToNot(HaveOccurred())
+
+	return string(content)
+}
+
 var _ = BeforeEach(func() {
+	inputDir = GinkgoT().TempDir()
+
 	server = httptest.NewServer(
 		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			requestURL = r.URL
-			fmt.Fprintln(w, responseBody)
+
+			if body, found := responses[r.URL.RequestURI()]; found {
+				fmt.Fprintln(w, body)
+			} else if body, found := responses[r.URL.Path]; found {
+				fmt.Fprintln(w, body)
+			} else {
+				fmt.Fprintln(w, responseBody)
+			}
 		}))
 
 	resource = xr.ConcourseResource[xr.Source, xr.Version, xr.Params]{
@@ -39,5 +60,7 @@ var _ = BeforeEach(func() {
 
 var _ = AfterEach(func() {
 	responseBody = "" // make sure we are not re-using it
+	responses = nil
+	requestURL = nil
 	server.Close()
 })
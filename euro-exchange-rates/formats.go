@@ -0,0 +1,140 @@
+package euroexchangerates
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"slices"
+
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+	"gopkg.in/yaml.v3"
+)
+
+// Formats of the documents Get writes in addition to the per-currency files
+const (
+	JSONFormat       = "json"
+	YAMLFormat       = "yaml"
+	CSVFormat        = "csv"
+	EnvFormat        = "env"
+	PropertiesFormat = "properties"
+)
+
+var formatters = map[string]struct {
+	file   string
+	format func(*frankfurter.ExchangeRates) ([]byte, error)
+}{
+	JSONFormat:       {"rates.json", formatJSON},
+	YAMLFormat:       {"rates.yml", formatYAML},
+	CSVFormat:        {"rates.csv", formatCSV},
+	EnvFormat:        {"rates.env", formatEnv},
+	PropertiesFormat: {"rates.properties", formatProperties},
+}
+
+func writeFormats(destination string, formats []string, rates *frankfurter.ExchangeRates) error {
+	for _, format := range formats {
+		formatter, found := formatters[format]
+
+		if !found {
+			return fmt.Errorf("unknown format %q", format)
+		}
+
+		content, err := formatter.format(rates)
+
+		if err != nil {
+			return fmt.Errorf("unable to format rates as %s: %w", format, err)
+		}
+
+		err = os.WriteFile(path.Join(destination, formatter.file), content, 0644)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ratesDocument is what the JSON and YAML formats contain. Maps are written with sorted keys by both encoders.
+type ratesDocument struct {
+	Date   string                           `json:"date" yaml:"date"`
+	Base   frankfurter.Currency             `json:"base" yaml:"base"`
+	Amount float32                          `json:"amount" yaml:"amount"`
+	Rates  map[frankfurter.Currency]float32 `json:"rates" yaml:"rates"`
+}
+
+func newRatesDocument(rates *frankfurter.ExchangeRates) ratesDocument {
+	return ratesDocument{Date: rates.Date.String(), Base: rates.Base, Amount: rates.Amount, Rates: rates.Rates}
+}
+
+func formatJSON(rates *frankfurter.ExchangeRates) ([]byte, error) {
+	return json.MarshalIndent(newRatesDocument(rates), "", "  ")
+}
+
+func formatYAML(rates *frankfurter.ExchangeRates) ([]byte, error) {
+	return yaml.Marshal(newRatesDocument(rates))
+}
+
+func formatCSV(rates *frankfurter.ExchangeRates) ([]byte, error) {
+	var buffer bytes.Buffer
+	w := csv.NewWriter(&buffer)
+	w.Write([]string{"date", "base", "currency", "rate"})
+
+	for _, currency := range sortedCurrencies(rates.Rates) {
+		w.Write([]string{rates.Date.String(), string(rates.Base), string(currency), rateString(rates.Rates[currency])})
+	}
+
+	w.Flush()
+
+	return buffer.Bytes(), w.Error()
+}
+
+// formatEnv writes one shell variable per currency, e.g. EUR_USD=1.0882
+func formatEnv(rates *frankfurter.ExchangeRates) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	for _, currency := range sortedCurrencies(rates.Rates) {
+		fmt.Fprintf(&buffer, "%s_%s=%s\n", rates.Base, currency, rateString(rates.Rates[currency]))
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func formatProperties(rates *frankfurter.ExchangeRates) ([]byte, error) {
+	var buffer bytes.Buffer
+
+	fmt.Fprintf(&buffer, "date=%s\n", rates.Date)
+	fmt.Fprintf(&buffer, "base=%s\n", rates.Base)
+	fmt.Fprintf(&buffer, "amount=%s\n", rateString(rates.Amount))
+
+	for _, currency := range sortedCurrencies(rates.Rates) {
+		fmt.Fprintf(&buffer, "rates.%s=%s\n", currency, rateString(rates.Rates[currency]))
+	}
+
+	return buffer.Bytes(), nil
+}
+
+func sortedCurrencies(rates frankfurter.Rates) []frankfurter.Currency {
+	currencies := make([]frankfurter.Currency, 0, len(rates))
+
+	for currency := range rates {
+		currencies = append(currencies, currency)
+	}
+
+	slices.Sort(currencies)
+
+	return currencies
+}
+
+// currencyCodes returns the codes of the currencies as strings, e.g. for CSV headers
+func currencyCodes(currencies []frankfurter.Currency) []string {
+	codes := make([]string, len(currencies))
+
+	for i, c := range currencies {
+		codes[i] = string(c)
+	}
+
+	return codes
+}
@@ -0,0 +1,86 @@
+package euroexchangerates_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/concourse-resource-go"
+	xr "github.com/suhlig/euro-exchange-rates-resource/euro-exchange-rates"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Formats", func() {
+	var (
+		err     error
+		request concourse.GetRequest[xr.Source, xr.Version, xr.Params]
+	)
+
+	BeforeEach(func() {
+		request = concourse.GetRequest[xr.Source, xr.Version, xr.Params]{}
+		request.Source.URL = server.URL
+		request.Source.Currencies = []frankfurter.Currency{frankfurter.Currency("USD"), frankfurter.Currency("SEK")}
+
+		date, e := frankfurter.NewYMD("2024-01-15")
+		Expect(e).ToNot(HaveOccurred())
+		request.Version = xr.Version{Date: date}
+
+		responseBody = `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "USD": 1.0882, "SEK": 11.3215 } }`
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		_, err = resource.Get(ctx, request, GinkgoWriter, inputDir)
+	})
+
+	Context("none requested", func() {
+		It("writes the per-currency files only", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filepath.Join(inputDir, "rates.json")).ToNot(BeAnExistingFile())
+			Expect(file("USD")).To(Equal("1.0882"))
+		})
+	})
+
+	Context("all formats", func() {
+		BeforeEach(func() {
+			request.Params.Formats = []string{xr.JSONFormat, xr.YAMLFormat, xr.CSVFormat, xr.EnvFormat, xr.PropertiesFormat}
+		})
+
+		It("works", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("still writes the per-currency files", func() {
+			Expect(file("SEK")).To(Equal("11.3215"))
+		})
+
+		It("writes JSON", func() {
+			Expect(file("rates.json")).To(MatchJSON(`{ "date": "2024-01-15", "base": "EUR", "amount": 1, "rates": { "SEK": 11.3215, "USD": 1.0882 } }`))
+		})
+
+		It("writes YAML", func() {
+			Expect(file("rates.yml")).To(MatchYAML(`{ date: "2024-01-15", base: EUR, amount: 1, rates: { SEK: 11.3215, USD: 1.0882 } }`))
+		})
+
+		It("writes CSV ordered by currency", func() {
+			Expect(file("rates.csv")).To(Equal("date,base,currency,rate\n2024-01-15,EUR,SEK,11.3215\n2024-01-15,EUR,USD,1.0882\n"))
+		})
+
+		It("writes a shell-sourceable file", func() {
+			Expect(file("rates.env")).To(Equal("EUR_SEK=11.3215\nEUR_USD=1.0882\n"))
+		})
+
+		It("writes Java properties", func() {
+			Expect(file("rates.properties")).To(Equal("date=2024-01-15\nbase=EUR\namount=1\nrates.SEK=11.3215\nrates.USD=1.0882\n"))
+		})
+	})
+
+	Context("unknown format", func() {
+		BeforeEach(func() {
+			request.Params.Formats = []string{"xml"}
+		})
+
+		It("is rejected by the request validation", func() {
+			Expect(request.Validate()).To(MatchError(ContainSubstring("Formats[0]")))
+		})
+	})
+})
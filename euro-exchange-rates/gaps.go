@@ -0,0 +1,110 @@
+package euroexchangerates
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+// Policies for business days that are missing in a history
+const (
+	IgnoreGaps  = "ignore"
+	WarnOnGaps  = "warn" // default
+	FailOnGaps  = "fail"
+	RefetchGaps = "refetch"
+)
+
+// missingBetween returns the business days after previous and before date, for which no rates were returned
+func missingBetween(previous, date frankfurter.YMD) []frankfurter.YMD {
+	var missing []frankfurter.YMD
+
+	target.Each(time.Time(previous.AddDays(1)), time.Time(date.AddDays(-1)), func(t time.Time) bool {
+		missing = append(missing, frankfurter.YMD(t))
+		return true
+	})
+
+	return missing
+}
+
+// gaps compares consecutive dates of a history with the business days of the ECB as the history is streamed, and
+// acts on missing ones according to the configured policy. Unless refetching, they are reported once the whole
+// history was seen.
+type gaps struct {
+	source        Source
+	log           io.Writer
+	missing       []frankfurter.YMD
+	unrecoverable []frankfurter.YMD
+}
+
+// fill handles the business days missing between the previous date and the given one. When refetching, the rates
+// recovered for them are returned in chronological order.
+func (g *gaps) fill(ctx context.Context, service frankfurter.ExchangeRatesService, previous, date frankfurter.YMD, currencies ...frankfurter.Currency) ([]*frankfurter.ExchangeRates, error) {
+	if g.source.Gaps == IgnoreGaps || previous.IsZero() {
+		return nil, nil
+	}
+
+	missing := missingBetween(previous, date)
+
+	if len(missing) == 0 {
+		return nil, nil
+	}
+
+	g.missing = append(g.missing, missing...)
+
+	if g.source.Gaps != RefetchGaps {
+		return nil, nil
+	}
+
+	fmt.Fprintf(g.log, "Rates are missing for %d business day(s); refetching %s\n", len(missing), joinDates(missing))
+
+	var recovered []*frankfurter.ExchangeRates
+
+	for _, date := range missing {
+		rates, err := service.At(ctx, date, currencies...)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to refetch rates as of %s: %w", date, err)
+		}
+
+		if !rates.Date.Equal(date) {
+			g.unrecoverable = append(g.unrecoverable, date)
+			continue
+		}
+
+		recovered = append(recovered, rates)
+	}
+
+	return recovered, nil
+}
+
+// done reports the business days that are missing (or could not be recovered) in the whole history
+func (g *gaps) done() error {
+	switch {
+	case len(g.missing) == 0:
+		return nil
+	case g.source.Gaps == FailOnGaps:
+		return fmt.Errorf("rates are missing for %d business day(s): %s", len(g.missing), joinDates(g.missing))
+	case g.source.Gaps == RefetchGaps:
+		if len(g.unrecoverable) > 0 {
+			fmt.Fprintf(g.log, "Warning: rates are still missing for %s\n", joinDates(g.unrecoverable))
+		}
+	default:
+		fmt.Fprintf(g.log, "Warning: rates are missing for %d business day(s): %s\n", len(g.missing), joinDates(g.missing))
+	}
+
+	return nil
+}
+
+func joinDates(dates []frankfurter.YMD) string {
+	s := make([]string, len(dates))
+
+	for i, date := range dates {
+		s[i] = date.String()
+	}
+
+	return strings.Join(s, ", ")
+}
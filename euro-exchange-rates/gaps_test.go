@@ -0,0 +1,110 @@
+package euroexchangerates_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/concourse-resource-go"
+	xr "github.com/suhlig/euro-exchange-rates-resource/euro-exchange-rates"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Gap detection", func() {
+	var (
+		err      error
+		request  concourse.CheckRequest[xr.Source, xr.Version]
+		response concourse.CheckResponse[xr.Version]
+	)
+
+	BeforeEach(func() {
+		request = concourse.CheckRequest[xr.Source, xr.Version]{}
+		request.Source.URL = server.URL
+
+		date, e := frankfurter.NewYMD("2024-01-15")
+		Expect(e).ToNot(HaveOccurred())
+		request.Version = xr.Version{Date: date}
+
+		responses = map[string]string{
+			"/2024-01-15..": `
+				{
+					"amount": 1.0,
+					"base": "EUR",
+					"start_date": "2024-01-15",
+					"end_date": "2024-01-18",
+					"rates": {
+						"2024-01-15": { "USD": 1.0887 },
+						"2024-01-16": { "USD": 1.089 },
+						"2024-01-18": { "USD": 1.0875 }
+					}
+				}
+			`,
+			"/2024-01-17": `
+				{
+					"amount": 1.0,
+					"base": "EUR",
+					"date": "2024-01-17",
+					"rates": { "USD": 1.0872 }
+				}
+			`,
+		}
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		response, err = resource.Check(ctx, request, GinkgoWriter)
+	})
+
+	Context("default policy", func() {
+		It("works", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("emits the returned dates only", func() {
+			Expect(response).To(HaveLen(3))
+		})
+	})
+
+	Context("failing on gaps", func() {
+		BeforeEach(func() {
+			request.Source.Gaps = xr.FailOnGaps
+		})
+
+		It("reports the missing date", func() {
+			Expect(err).To(MatchError(ContainSubstring("missing for 1 business day(s): 2024-01-17")))
+		})
+	})
+
+	Context("refetching gaps", func() {
+		BeforeEach(func() {
+			request.Source.Gaps = xr.RefetchGaps
+		})
+
+		It("works", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("fills the gap", func() {
+			Expect(response).To(HaveLen(4))
+			Expect(response[2].Date.String()).To(Equal("2024-01-17"))
+		})
+
+		Context("upstream does not have the date either", func() {
+			BeforeEach(func() {
+				responses["/2024-01-17"] = `{ "amount": 1.0, "base": "EUR", "date": "2024-01-16", "rates": { "USD": 1.089 } }`
+			})
+
+			It("still works", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).To(HaveLen(3))
+			})
+		})
+	})
+
+	Context("unknown policy", func() {
+		BeforeEach(func() {
+			request.Source.Gaps = "panic"
+		})
+
+		It("is rejected by the request validation", func() {
+			Expect(request.Validate()).To(MatchError(ContainSubstring("Gaps")))
+		})
+	})
+})
@@ -0,0 +1,155 @@
+package euroexchangerates
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"time"
+
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+// Window selects the rates preceding the version date that Get writes as a time series. It either spans a number of
+// business days, or starts at a given date. Both end at the version date.
+type Window struct {
+	Days        int             `json:"days" validate:"omitempty,gt=0"`
+	Since       frankfurter.YMD `json:"since"`
+	FillForward bool            `json:"fill_forward"` // repeat the last rates on weekends and holidays
+}
+
+func validateWindow(w *Window) error {
+	if w == nil {
+		return nil
+	}
+
+	if (w.Days == 0) == w.Since.IsZero() {
+		return errors.New("history needs either a number of days or a date since when")
+	}
+
+	return nil
+}
+
+// start returns the first date of the window ending at the given date
+func (w Window) start(end frankfurter.YMD) (frankfurter.YMD, error) {
+	if !w.Since.IsZero() {
+		if end.Before(w.Since) {
+			return frankfurter.YMD{}, fmt.Errorf("history should start at %s, which is after %s", w.Since, end)
+		}
+
+		return w.Since, nil
+	}
+
+	start := time.Time(end)
+
+	for i := 1; i < w.Days; i++ {
+		start = target.Previous(start)
+	}
+
+	return frankfurter.YMD(start), nil
+}
+
+type observation struct {
+	Date     string               `json:"date"`
+	Currency frankfurter.Currency `json:"currency"`
+	Rate     float32              `json:"rate"`
+}
+
+// series flattens the history into observations ordered by date and currency. If filling forward, every calendar
+// day from start to end has observations, repeating the last publication on days without one.
+func series(history *frankfurter.History, start, end frankfurter.YMD, fillForward bool) []observation {
+	published := make(map[string]frankfurter.Rates, len(history.Rates))
+
+	for date, rates := range history.Rates {
+		published[date.String()] = rates
+	}
+
+	var (
+		observations []observation
+		last         frankfurter.Rates
+	)
+
+	for day := start; !end.Before(day); day = day.AddDays(1) {
+		rates, found := published[day.String()]
+
+		if found {
+			last = rates
+		} else if fillForward {
+			rates = last
+		}
+
+		for _, currency := range sortedCurrencies(rates) {
+			observations = append(observations, observation{Date: day.String(), Currency: currency, Rate: rates[currency]})
+		}
+	}
+
+	return observations
+}
+
+// windowHistory fetches the rates of the window ending at the given date, converted as the params ask for. It also
+// returns the first date of the window.
+func windowHistory(ctx context.Context, service frankfurter.ExchangeRatesService, params Params, source Source, end frankfurter.YMD) (*frankfurter.History, frankfurter.YMD, error) {
+	start, err := params.History.start(end)
+
+	if err != nil {
+		return nil, frankfurter.YMD{}, err
+	}
+
+	currencies, err := params.outputCurrencies(source)
+
+	if err != nil {
+		return nil, frankfurter.YMD{}, err
+	}
+
+	history, err := params.outputService(service).Between(ctx, start, end, currencies...)
+
+	if err != nil {
+		return nil, frankfurter.YMD{}, fmt.Errorf("unable to fetch the history from %s until %s: %w", start, end, err)
+	}
+
+	if history.Base != params.base() {
+		return nil, frankfurter.YMD{}, fmt.Errorf("history was requested based on %s, but is based on %s", params.base(), history.Base)
+	}
+
+	return history, start, nil
+}
+
+// writeHistory writes the observations as history.csv and history.jsonl
+func writeHistory(destination string, observations []observation) error {
+	var buffer bytes.Buffer
+	w := csv.NewWriter(&buffer)
+	w.Write([]string{"date", "currency", "rate"})
+
+	for _, o := range observations {
+		w.Write([]string{o.Date, string(o.Currency), rateString(o.Rate)})
+	}
+
+	w.Flush()
+
+	if w.Error() != nil {
+		return w.Error()
+	}
+
+	err := os.WriteFile(path.Join(destination, "history.csv"), buffer.Bytes(), 0644)
+
+	if err != nil {
+		return err
+	}
+
+	buffer.Reset()
+	encoder := json.NewEncoder(&buffer)
+
+	for _, o := range observations {
+		err = encoder.Encode(o)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return os.WriteFile(path.Join(destination, "history.jsonl"), buffer.Bytes(), 0644)
+}
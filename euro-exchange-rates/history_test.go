@@ -0,0 +1,165 @@
+package euroexchangerates_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/concourse-resource-go"
+	xr "github.com/suhlig/euro-exchange-rates-resource/euro-exchange-rates"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("History", func() {
+	var (
+		err     error
+		request concourse.GetRequest[xr.Source, xr.Version, xr.Params]
+	)
+
+	BeforeEach(func() {
+		request = concourse.GetRequest[xr.Source, xr.Version, xr.Params]{}
+		request.Source.URL = server.URL
+		request.Source.Currencies = []frankfurter.Currency{frankfurter.Currency("USD"), frankfurter.Currency("SEK")}
+
+		date, e := frankfurter.NewYMD("2024-01-15")
+		Expect(e).ToNot(HaveOccurred())
+		request.Version = xr.Version{Date: date}
+
+		responses = map[string]string{
+			"/2024-01-15": `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "USD": 1.0882, "SEK": 11.3215 } }`,
+			"/2024-01-11..2024-01-15": `
+				{
+					"amount": 1.0,
+					"base": "EUR",
+					"start_date": "2024-01-11",
+					"end_date": "2024-01-15",
+					"rates": {
+						"2024-01-11": { "USD": 1.0987, "SEK": 11.2545 },
+						"2024-01-12": { "USD": 1.0942, "SEK": 11.2615 },
+						"2024-01-15": { "USD": 1.0882, "SEK": 11.3215 }
+					}
+				}
+			`,
+		}
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		_, err = resource.Get(ctx, request, GinkgoWriter, inputDir)
+	})
+
+	Context("not requested", func() {
+		It("writes no history", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(filepath.Join(inputDir, "history.csv")).ToNot(BeAnExistingFile())
+		})
+	})
+
+	Context("a number of business days", func() {
+		BeforeEach(func() {
+			request.Params.History = &xr.Window{Days: 3}
+		})
+
+		It("works", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("writes a tidy CSV", func() {
+			Expect(file("history.csv")).To(Equal(`date,currency,rate
+2024-01-11,SEK,11.2545
+2024-01-11,USD,1.0987
+2024-01-12,SEK,11.2615
+2024-01-12,USD,1.0942
+2024-01-15,SEK,11.3215
+2024-01-15,USD,1.0882
+`))
+		})
+
+		It("writes JSON Lines", func() {
+			Expect(file("history.jsonl")).To(HavePrefix(`{"date":"2024-01-11","currency":"SEK","rate":11.2545}
+{"date":"2024-01-11","currency":"USD","rate":1.0987}
+`))
+		})
+
+		Context("filling forward", func() {
+			BeforeEach(func() {
+				request.Params.History.FillForward = true
+			})
+
+			It("repeats Friday's rates on the weekend", func() {
+				Expect(file("history.csv")).To(ContainSubstring(`2024-01-12,USD,1.0942
+2024-01-13,SEK,11.2615
+2024-01-13,USD,1.0942
+2024-01-14,SEK,11.2615
+2024-01-14,USD,1.0942
+2024-01-15,SEK,11.3215
+`))
+			})
+		})
+	})
+
+	Context("since a date", func() {
+		BeforeEach(func() {
+			request.Params.History = &xr.Window{Since: request.Version.Date.AddDays(-4)}
+		})
+
+		It("fetches the range", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file("history.csv")).To(HavePrefix("date,currency,rate\n2024-01-11,SEK,11.2545\n"))
+		})
+	})
+
+	Context("a narrower set of currencies", func() {
+		BeforeEach(func() {
+			request.Params.History = &xr.Window{Days: 3}
+			request.Params.Currencies = []frankfurter.Currency{frankfurter.Currency("USD")}
+			responses["/2024-01-11..2024-01-15?to=USD"] = `
+				{
+					"amount": 1.0,
+					"base": "EUR",
+					"start_date": "2024-01-11",
+					"end_date": "2024-01-15",
+					"rates": {
+						"2024-01-11": { "USD": 1.0987 },
+						"2024-01-12": { "USD": 1.0942 },
+						"2024-01-15": { "USD": 1.0882 }
+					}
+				}
+			`
+		})
+
+		It("only asks for those", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file("history.csv")).ToNot(ContainSubstring("SEK"))
+		})
+	})
+
+	Context("neither days nor a date", func() {
+		BeforeEach(func() {
+			request.Params.History = &xr.Window{FillForward: true}
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring("history needs either a number of days or a date since when")))
+		})
+	})
+
+	Context("a negative number of days", func() {
+		BeforeEach(func() {
+			request.Params.History = &xr.Window{Days: -1}
+		})
+
+		It("is rejected by the request validation", func() {
+			Expect(request.Validate()).To(MatchError(ContainSubstring("History.Days")))
+		})
+	})
+
+	Context("a start after the version date", func() {
+		BeforeEach(func() {
+			request.Params.History = &xr.Window{Since: request.Version.Date.AddDays(1)}
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring("history should start at 2024-01-16, which is after 2024-01-15")))
+		})
+	})
+})
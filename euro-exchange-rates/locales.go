@@ -0,0 +1,115 @@
+package euroexchangerates
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path"
+
+	"github.com/suhlig/euro-exchange-rates-resource/currency"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+func validateLocales(locales []string) error {
+	var errs []error
+
+	for _, l := range locales {
+		_, err := currency.ParseLocale(l)
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+type formattedConversion struct {
+	Amount   string               `json:"amount"`
+	Currency frankfurter.Currency `json:"currency"`
+	Value    string               `json:"value"`
+}
+
+// formatted has the rates and conversions written for humans in the way of a locale
+type formatted struct {
+	Locale      string                          `json:"locale"`
+	Date        frankfurter.YMD                 `json:"date"`
+	Rates       map[frankfurter.Currency]string `json:"rates"`
+	Conversions []formattedConversion           `json:"conversions,omitempty"`
+}
+
+func newFormatted(locale currency.Locale, rates *frankfurter.ExchangeRates, table *conversionTable) (formatted, error) {
+	f := formatted{
+		Locale: locale.String(),
+		Date:   rates.Date,
+		Rates:  make(map[frankfurter.Currency]string, len(rates.Rates)),
+	}
+
+	for c, rate := range rates.Rates {
+		f.Rates[c] = locale.FormatNumber(rateString(rate))
+	}
+
+	if table == nil {
+		return f, nil
+	}
+
+	mode, err := currency.ParseRoundingMode(table.Rounding)
+
+	if err != nil {
+		return formatted{}, err
+	}
+
+	for _, c := range table.Conversions {
+		f.Conversions = append(f.Conversions, formattedConversion{
+			Amount:   locale.FormatAmount(c.exact.amount, iso(table.From), mode),
+			Currency: c.Currency,
+			Value:    locale.FormatAmount(c.exact.value, iso(c.Currency), mode),
+		})
+	}
+
+	return f, nil
+}
+
+// writeFormatted writes formatted/<locale>.json and a file formatted/<locale>/<currency> per rate for each locale
+func writeFormatted(destination string, locales []string, rates *frankfurter.ExchangeRates, table *conversionTable) error {
+	for _, l := range locales {
+		locale, err := currency.ParseLocale(l)
+
+		if err != nil {
+			return err
+		}
+
+		f, err := newFormatted(locale, rates, table)
+
+		if err != nil {
+			return err
+		}
+
+		directory := path.Join(destination, "formatted", f.Locale)
+
+		err = os.MkdirAll(directory, 0755)
+
+		if err != nil {
+			return err
+		}
+
+		for c, rate := range f.Rates {
+			err = os.WriteFile(path.Join(directory, string(c)), []byte(rate), 0644)
+
+			if err != nil {
+				return err
+			}
+		}
+
+		content, err := json.MarshalIndent(f, "", "  ")
+
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(path.Join(destination, "formatted", f.Locale+".json"), content, 0644)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package euroexchangerates_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/concourse-resource-go"
+	xr "github.com/suhlig/euro-exchange-rates-resource/euro-exchange-rates"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Locales", func() {
+	var (
+		err     error
+		request concourse.GetRequest[xr.Source, xr.Version, xr.Params]
+	)
+
+	BeforeEach(func() {
+		request = concourse.GetRequest[xr.Source, xr.Version, xr.Params]{}
+		request.Source.URL = server.URL
+		request.Source.Currencies = []frankfurter.Currency{frankfurter.Currency("USD"), frankfurter.Currency("JPY")}
+		request.Params.Locales = []string{"de-DE", "en-US"}
+
+		date, e := frankfurter.NewYMD("2024-01-15")
+		Expect(e).ToNot(HaveOccurred())
+		request.Version = xr.Version{Date: date}
+
+		responseBody = `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "JPY": 160.52, "USD": 1.0882 } }`
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		_, err = resource.Get(ctx, request, GinkgoWriter, inputDir)
+	})
+
+	It("works", func() {
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("writes a file per locale and currency", func() {
+		Expect(file("formatted/de-DE/USD")).To(Equal("1,0882"))
+		Expect(file("formatted/en-US/JPY")).To(Equal("160.52"))
+	})
+
+	It("writes the rates as JSON", func() {
+		Expect(file("formatted/de-DE.json")).To(MatchJSON(`{ "locale": "de-DE", "date": "2024-01-15", "rates": { "JPY": "160,52", "USD": "1,0882" } }`))
+	})
+
+	Context("with conversions", func() {
+		BeforeEach(func() {
+			request.Params.Convert = &xr.Convert{Amounts: []float64{1000}}
+		})
+
+		It("formats amounts with their currency", func() {
+			Expect(file("formatted/de-DE.json")).To(MatchJSON(`
+				{
+					"locale": "de-DE",
+					"date": "2024-01-15",
+					"rates": { "JPY": "160,52", "USD": "1,0882" },
+					"conversions": [
+						{ "amount": "1.000,00\u00a0€", "currency": "JPY", "value": "160.520\u00a0¥" },
+						{ "amount": "1.000,00\u00a0€", "currency": "USD", "value": "1.088,20\u00a0$" }
+					]
+				}
+			`))
+		})
+
+		It("follows the conventions of each locale", func() {
+			Expect(file("formatted/en-US.json")).To(ContainSubstring(`"value": "$1,088.20"`))
+		})
+	})
+
+	Context("a regional variant", func() {
+		BeforeEach(func() {
+			request.Params.Locales = []string{"es-MX"}
+			request.Params.Convert = &xr.Convert{Amounts: []float64{1000}}
+		})
+
+		It("follows the conventions of the region", func() {
+			Expect(file("formatted/es-MX/USD")).To(Equal("1.0882"))
+			Expect(file("formatted/es-MX.json")).To(ContainSubstring("\"amount\": \"EUR\u00a01,000.00\""))
+		})
+	})
+
+	Context("an unknown locale", func() {
+		BeforeEach(func() {
+			request.Params.Locales = []string{"xx"}
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring(`unable to parse locale "xx"`)))
+		})
+	})
+})
@@ -0,0 +1,115 @@
+package euroexchangerates
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+// urls returns the configured URL followed by the mirrors, if any
+func (s Source) urls() []string {
+	if s.URL == "" {
+		return s.Mirrors
+	}
+
+	return append([]string{s.URL}, s.Mirrors...)
+}
+
+// endpoint describes where rates are fetched from, for use in messages
+func (s Source) endpoint() string {
+	return strings.Join(s.urls(), ", ")
+}
+
+func validateMirrors(source Source) error {
+	if source.CrossCheck && len(source.urls()) < 2 {
+		return fmt.Errorf("cross-checking requires at least two mirrors, but only %q is configured", source.endpoint())
+	}
+
+	return nil
+}
+
+// service creates the client for the configured URL, or for the mirrors if there are any. As every invocation of the
+// resource is a new process, round-robin starts with a mirror derived from the date of the version, so that successive
+// versions spread their requests over the mirrors.
+func (r ConcourseResource[S, V, P]) service(source Source, log io.Writer, date frankfurter.YMD) frankfurter.ExchangeRatesService {
+	service := frankfurter.ExchangeRatesService{URL: source.URL, HttpClient: r.HttpClient}
+
+	if len(source.Mirrors) > 0 {
+		service.Mirrors = &frankfurter.Mirrors{
+			URLs:     source.urls(),
+			Strategy: frankfurter.Strategy(source.MirrorStrategy),
+			Log:      log,
+			Offset:   rotation(date),
+		}
+	}
+
+	return service
+}
+
+// rotation numbers the days since the Unix epoch; zero for no date
+func rotation(date frankfurter.YMD) uint32 {
+	if date.IsZero() {
+		return 0
+	}
+
+	return uint32(time.Time(date).Unix() / (24 * 60 * 60))
+}
+
+// crossCheck fetches the rates from every mirror and fails unless all of them agree with the given rates.
+func (r ConcourseResource[S, V, P]) crossCheck(ctx context.Context, log io.Writer, source Source, rates *frankfurter.ExchangeRates) error {
+	currencies := source.Currencies
+
+	if len(currencies) == 0 {
+		for currency := range rates.Rates {
+			currencies = append(currencies, currency)
+		}
+	}
+
+	currencies = slices.Clone(currencies)
+	slices.Sort(currencies)
+
+	var disagreements []string
+
+	for _, mirror := range source.urls() {
+		fmt.Fprintf(log, "Cross-checking rates as of %s with %s\n", rates.Date, mirror)
+
+		other, err := frankfurter.ExchangeRatesService{URL: mirror, HttpClient: r.HttpClient}.At(ctx, rates.Date, source.Currencies...)
+
+		if err != nil {
+			return fmt.Errorf("unable to cross-check rates as of %s with %s: %w", rates.Date, mirror, err)
+		}
+
+		if !other.Date.Equal(rates.Date) {
+			disagreements = append(disagreements, fmt.Sprintf("%s serves %s instead of %s", mirror, other.Date, rates.Date))
+			continue
+		}
+
+		for _, currency := range currencies {
+			expected, found := rates.Rates[currency]
+			actual, otherFound := other.Rates[currency]
+
+			if found != otherFound || expected != actual {
+				disagreements = append(disagreements, fmt.Sprintf("%s: %s has %s, but %s was served", currency, mirror, rateOrMissing(actual, otherFound), rateOrMissing(expected, found)))
+			}
+		}
+	}
+
+	if len(disagreements) > 0 {
+		return fmt.Errorf("mirrors disagree on the rates as of %s:\n%s", rates.Date, strings.Join(disagreements, "\n"))
+	}
+
+	return nil
+}
+
+func rateOrMissing(rate float32, found bool) string {
+	if !found {
+		return "no rate"
+	}
+
+	return rateString(rate)
+}
@@ -0,0 +1,106 @@
+package euroexchangerates_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/concourse-resource-go"
+	xr "github.com/suhlig/euro-exchange-rates-resource/euro-exchange-rates"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Mirrors", func() {
+	var (
+		err      error
+		request  concourse.GetRequest[xr.Source, xr.Version, xr.Params]
+		broken   *httptest.Server
+		other    *httptest.Server
+		otherUSD string
+		log      *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		log = &bytes.Buffer{}
+		otherUSD = "1.0882"
+
+		broken = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+
+		other = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "SEK": 11.3215, "USD": %s } }`, otherUSD)
+		}))
+
+		request = concourse.GetRequest[xr.Source, xr.Version, xr.Params]{}
+		request.Source.Mirrors = []string{broken.URL, server.URL}
+
+		date, e := frankfurter.NewYMD("2024-01-15")
+		Expect(e).ToNot(HaveOccurred())
+		request.Version = xr.Version{Date: date}
+
+		responseBody = `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "SEK": 11.3215, "USD": 1.0882 } }`
+	})
+
+	AfterEach(func() {
+		broken.Close()
+		other.Close()
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		_, err = resource.Get(ctx, request, log, GinkgoT().TempDir())
+	})
+
+	It("fails over to the next mirror", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(requestURL.Path).To(Equal("/2024-01-15"))
+	})
+
+	It("logs which mirror served the data", func() {
+		Expect(log.String()).To(ContainSubstring("Served 2024-01-15 from mirror " + server.URL))
+	})
+
+	Context("all mirrors broken", func() {
+		BeforeEach(func() {
+			request.Source.Mirrors = []string{broken.URL}
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring("no mirror could serve")))
+		})
+	})
+
+	Context("cross-checking", func() {
+		BeforeEach(func() {
+			request.Source.Mirrors = []string{server.URL, other.URL}
+			request.Source.CrossCheck = true
+		})
+
+		It("works if the mirrors agree", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("mirrors disagree", func() {
+			BeforeEach(func() {
+				otherUSD = "1.09"
+			})
+
+			It("names the currency and the mirror", func() {
+				Expect(err).To(MatchError(ContainSubstring("USD: %s has 1.09, but 1.0882 was served", other.URL)))
+			})
+		})
+	})
+
+	Context("unknown strategy", func() {
+		BeforeEach(func() {
+			request.Source.MirrorStrategy = "random"
+		})
+
+		It("is rejected by the request validation", func() {
+			Expect(request.Validate()).To(MatchError(ContainSubstring("MirrorStrategy")))
+		})
+	})
+})
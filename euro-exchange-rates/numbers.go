@@ -0,0 +1,31 @@
+package euroexchangerates
+
+import (
+	"math"
+	"strconv"
+)
+
+// decimal converts a rate to float64 as it was published, avoiding the noise of the float32 representation
+func decimal(rate float32) float64 {
+	d, _ := strconv.ParseFloat(rateString(rate), 64)
+	return d
+}
+
+// difference subtracts b from a in decimal terms, avoiding the noise that float32 arithmetic would introduce.
+func difference(a, b float32) float64 {
+	return round(decimal(a) - decimal(b))
+}
+
+func round(x float64) float64 {
+	return math.Round(x*1e8) / 1e8
+}
+
+func sum(values []float64) float64 {
+	var total float64
+
+	for _, v := range values {
+		total += v
+	}
+
+	return total
+}
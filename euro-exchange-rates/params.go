@@ -0,0 +1,133 @@
+package euroexchangerates
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+// validate checks the params in combination with the source they narrow
+func (p Params) validate(source Source) error {
+	var errs []error
+
+	if len(source.Currencies) > 0 {
+		for _, currency := range p.Currencies {
+			if !slices.Contains(source.Currencies, currency) {
+				errs = append(errs, fmt.Errorf("currency %s requested, but it is not among the configured currencies %s", currency, source.Currencies))
+			}
+		}
+	}
+
+	if p.Directory != "" && !filepath.IsLocal(p.Directory) {
+		errs = append(errs, fmt.Errorf("directory %s must be relative and must not leave the destination", p.Directory))
+	}
+
+	return errors.Join(append(errs, validateWindow(p.History), validateStatistics(p), validateCandles(p), validateLocales(p.Locales), validateTemplates(p.Templates))...)
+}
+
+// currencies returns the currencies to write, which default to the configured ones
+func (p Params) currencies(source Source) []frankfurter.Currency {
+	if len(p.Currencies) > 0 {
+		return p.Currencies
+	}
+
+	return source.Currencies
+}
+
+// converted tells whether the rates need to be fetched again for another base or amount
+func (p Params) converted() bool {
+	return (p.Base != "" && p.Base != euro) || (p.Amount != 0 && p.Amount != 1)
+}
+
+// base returns the base currency of the rates to write
+func (p Params) base() frankfurter.Currency {
+	if p.Base == "" {
+		return euro
+	}
+
+	return p.Base
+}
+
+// outputCurrencies returns the currencies to request for writing. The base is left out, as its rate is the amount.
+func (p Params) outputCurrencies(source Source) ([]frankfurter.Currency, error) {
+	currencies := p.currencies(source)
+
+	if len(currencies) == 0 {
+		return nil, nil
+	}
+
+	currencies = slices.DeleteFunc(slices.Clone(currencies), func(c frankfurter.Currency) bool { return c == p.base() })
+
+	if len(currencies) == 0 {
+		return nil, fmt.Errorf("no currencies left to convert to besides the base %s", p.base())
+	}
+
+	return currencies, nil
+}
+
+// outputService returns a service that converts to the requested base and amount
+func (p Params) outputService(service frankfurter.ExchangeRatesService) frankfurter.ExchangeRatesService {
+	service.Base = p.Base
+	service.Amount = p.Amount
+
+	return service
+}
+
+// outputRates returns the rates that Get writes. They were fetched and verified for the configured currencies, but
+// the params may narrow the currencies or ask for another base or amount. As the ECB only publishes EUR rates, the
+// latter are fetched again, letting the service do the conversion.
+func outputRates(ctx context.Context, service frankfurter.ExchangeRatesService, params Params, source Source, rates *frankfurter.ExchangeRates) (*frankfurter.ExchangeRates, error) {
+	if !params.converted() {
+		if len(params.Currencies) == 0 {
+			return rates, nil
+		}
+
+		narrowed := *rates
+		narrowed.Rates = make(frankfurter.Rates, len(params.Currencies))
+
+		for _, currency := range params.Currencies {
+			rate, found := rates.Rates[currency]
+
+			if !found {
+				return nil, fmt.Errorf("currency %s is not available", currency)
+			}
+
+			narrowed.Rates[currency] = rate
+		}
+
+		return &narrowed, nil
+	}
+
+	base := params.base()
+	currencies, err := params.outputCurrencies(source)
+
+	if err != nil {
+		return nil, err
+	}
+
+	converted, err := params.outputService(service).At(ctx, rates.Date, currencies...)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch rates as of %s based on %s: %w", rates.Date, base, err)
+	}
+
+	if !converted.Date.Equal(rates.Date) {
+		return nil, fmt.Errorf("rates based on %s are not available as of %s; closest is %s", base, rates.Date, converted.Date)
+	}
+
+	if converted.Base != base {
+		return nil, fmt.Errorf("rates were requested based on %s, but are based on %s", base, converted.Base)
+	}
+
+	for _, currency := range currencies {
+		if _, found := converted.Rates[currency]; !found {
+			return nil, fmt.Errorf("currency %s is not available based on %s", currency, base)
+		}
+	}
+
+	return converted, nil
+}
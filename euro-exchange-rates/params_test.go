@@ -0,0 +1,165 @@
+package euroexchangerates_test
+
+import (
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/concourse-resource-go"
+	xr "github.com/suhlig/euro-exchange-rates-resource/euro-exchange-rates"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Params", func() {
+	var (
+		err      error
+		request  concourse.GetRequest[xr.Source, xr.Version, xr.Params]
+		response *concourse.Response[xr.Version]
+	)
+
+	BeforeEach(func() {
+		request = concourse.GetRequest[xr.Source, xr.Version, xr.Params]{}
+		request.Source.URL = server.URL
+		request.Source.Currencies = []frankfurter.Currency{frankfurter.Currency("USD"), frankfurter.Currency("SEK")}
+
+		date, e := frankfurter.NewYMD("2024-01-15")
+		Expect(e).ToNot(HaveOccurred())
+		request.Version = xr.Version{Date: date}
+
+		responses = map[string]string{
+			"/2024-01-15?to=USD%2CSEK":               `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "USD": 1.0882, "SEK": 11.3215 } }`,
+			"/2024-01-15?amount=100&from=USD&to=SEK": `{ "amount": 100.0, "base": "USD", "date": "2024-01-15", "rates": { "SEK": 1040.38 } }`,
+			"/2024-01-15?amount=100&to=USD%2CSEK":    `{ "amount": 100.0, "base": "EUR", "date": "2024-01-15", "rates": { "USD": 108.82, "SEK": 1132.15 } }`,
+			"/2024-01-15?from=USD&to=SEK":            `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "SEK": 11.3215 } }`,
+		}
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		response, err = resource.Get(ctx, request, GinkgoWriter, inputDir)
+	})
+
+	Context("none", func() {
+		It("writes all configured currencies", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file("USD")).To(Equal("1.0882"))
+			Expect(file("SEK")).To(Equal("11.3215"))
+		})
+	})
+
+	Context("a subset of currencies", func() {
+		BeforeEach(func() {
+			request.Params.Currencies = []frankfurter.Currency{frankfurter.Currency("SEK")}
+		})
+
+		It("writes only those", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file("SEK")).To(Equal("11.3215"))
+			Expect(filepath.Join(inputDir, "USD")).ToNot(BeAnExistingFile())
+		})
+
+		It("has metadata only for those", func() {
+			Expect(response.Metadata).To(ConsistOf(concourse.NameValuePair{Name: "SEK", Value: "11.3215"}))
+		})
+	})
+
+	Context("a currency that is not configured", func() {
+		BeforeEach(func() {
+			request.Params.Currencies = []frankfurter.Currency{frankfurter.Currency("GBP")}
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring("currency GBP requested, but it is not among the configured currencies")))
+		})
+	})
+
+	Context("another base and amount", func() {
+		BeforeEach(func() {
+			request.Params.Currencies = []frankfurter.Currency{frankfurter.Currency("USD"), frankfurter.Currency("SEK")}
+			request.Params.Base = frankfurter.Currency("USD")
+			request.Params.Amount = 100
+			request.Params.Formats = []string{xr.JSONFormat}
+		})
+
+		It("works", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("writes the converted rates", func() {
+			Expect(file("SEK")).To(Equal("1040.38"))
+			Expect(filepath.Join(inputDir, "USD")).ToNot(BeAnExistingFile())
+		})
+
+		It("records base and amount", func() {
+			Expect(file("rates.json")).To(MatchJSON(`{ "date": "2024-01-15", "base": "USD", "amount": 100, "rates": { "SEK": 1040.38 } }`))
+		})
+
+		It("keeps the version", func() {
+			Expect(response.Version).To(Equal(request.Version))
+		})
+	})
+
+	Context("only an amount", func() {
+		BeforeEach(func() {
+			request.Params.Amount = 100
+		})
+
+		It("writes the multiplied rates", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file("USD")).To(Equal("108.82"))
+		})
+	})
+
+	Context("a base that the service does not honor", func() {
+		BeforeEach(func() {
+			request.Source.Currencies = []frankfurter.Currency{frankfurter.Currency("SEK")}
+			request.Params.Base = frankfurter.Currency("USD")
+			responses["/2024-01-15?to=SEK"] = `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "SEK": 11.3215 } }`
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring("rates were requested based on USD, but are based on EUR")))
+		})
+	})
+
+	Context("a lower-case base", func() {
+		BeforeEach(func() {
+			request.Params.Base = frankfurter.Currency("usd")
+		})
+
+		It("is rejected by the request validation", func() {
+			Expect(request.Validate()).To(MatchError(ContainSubstring("Base")))
+		})
+	})
+
+	Context("a negative amount", func() {
+		BeforeEach(func() {
+			request.Params.Amount = -1
+		})
+
+		It("is rejected by the request validation", func() {
+			Expect(request.Validate()).To(MatchError(ContainSubstring("Amount")))
+		})
+	})
+
+	Context("a subdirectory", func() {
+		BeforeEach(func() {
+			request.Params.Directory = "rates/today"
+		})
+
+		It("writes there", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(file("rates/today/USD")).To(Equal("1.0882"))
+			Expect(filepath.Join(inputDir, "USD")).ToNot(BeAnExistingFile())
+		})
+	})
+
+	Context("a directory outside of the destination", func() {
+		BeforeEach(func() {
+			request.Params.Directory = "../elsewhere"
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring("must not leave the destination")))
+		})
+	})
+})
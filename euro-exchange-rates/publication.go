@@ -0,0 +1,68 @@
+package euroexchangerates
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/suhlig/euro-exchange-rates-resource/calendar"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+// target tells business days from TARGET closing days
+var target = calendar.TARGET()
+
+// nextPublication returns the date after the given one on which the ECB is expected to publish reference rates.
+// As with all YMD values, the time of day is the one at which the rates are published.
+func nextPublication(after frankfurter.YMD) frankfurter.YMD {
+	return frankfurter.YMD(target.Next(time.Time(after)))
+}
+
+// warnAboutClosingDays logs each of the given dates that is a TARGET closing day, as no rates should exist for them.
+func warnAboutClosingDays(log io.Writer, dates ...frankfurter.YMD) {
+	for _, date := range dates {
+		if closure, closed := target.Closure(time.Time(date)); closed {
+			fmt.Fprintf(log, "Warning: rates were returned for %s, but TARGET is closed on that day (%s)\n", date, closure)
+		}
+	}
+}
+
+// direction in which to look for the publication adjacent to a date
+type direction int
+
+const (
+	backward direction = -1
+	forward  direction = 1
+)
+
+// adjacentRates fetches the rates of the last publication before the given date, or of the first one after it, or
+// nil if there is none. Only the window of calendar.MaxPublicationGap days next to the date is searched.
+func adjacentRates(ctx context.Context, service frankfurter.ExchangeRatesService, date frankfurter.YMD, d direction, currencies ...frankfurter.Currency) (*frankfurter.ExchangeRates, error) {
+	start, end := date.AddDays(int(d)), date.AddDays(int(d)*calendar.MaxPublicationGap)
+
+	if d == backward {
+		start, end = end, start
+	}
+
+	history, err := service.Between(ctx, start, end, currencies...)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch the rates adjacent to %s: %w", date, err)
+	}
+
+	dates := history.Dates()
+
+	if d == backward {
+		slices.Reverse(dates)
+	}
+
+	for _, adjacent := range dates {
+		if (d == backward && adjacent.Before(date)) || (d == forward && date.Before(adjacent)) {
+			return &frankfurter.ExchangeRates{Date: adjacent, Amount: history.Amount, Base: history.Base, Rates: history.Rates[adjacent]}, nil
+		}
+	}
+
+	return nil, nil
+}
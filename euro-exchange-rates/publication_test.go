@@ -0,0 +1,135 @@
+package euroexchangerates_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/concourse-resource-go"
+	xr "github.com/suhlig/euro-exchange-rates-resource/euro-exchange-rates"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Publication-time aware Check", func() {
+	var (
+		err      error
+		request  concourse.CheckRequest[xr.Source, xr.Version]
+		response concourse.CheckResponse[xr.Version]
+		now      time.Time
+		berlin   *time.Location
+	)
+
+	BeforeEach(func() {
+		var e error
+		berlin, e = time.LoadLocation("Europe/Berlin")
+		Expect(e).ToNot(HaveOccurred())
+
+		request = concourse.CheckRequest[xr.Source, xr.Version]{}
+		request.Source.URL = server.URL
+
+		responseBody = `
+			{
+				"amount": 1.0,
+				"base": "EUR",
+				"start_date": "2024-03-28",
+				"end_date": "2024-03-28",
+				"rates": { "2024-03-28": { "USD": 1.0811 } }
+			}
+		`
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		clocked := xr.ConcourseResource[xr.Source, xr.Version, xr.Params]{
+			HttpClient: server.Client(),
+			Clock:      func() time.Time { return now },
+		}
+
+		response, err = clocked.Check(ctx, request, GinkgoWriter)
+	})
+
+	Context("version of Maundy Thursday given", func() {
+		BeforeEach(func() {
+			thursday, e := frankfurter.NewYMD("2024-03-28")
+			Expect(e).ToNot(HaveOccurred())
+			request.Version = xr.Version{Date: thursday}
+		})
+
+		Context("on Easter Monday", func() {
+			BeforeEach(func() {
+				now = time.Date(2024, 4, 1, 18, 0, 0, 0, berlin)
+			})
+
+			It("works", func() {
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("does not query the API", func() {
+				Expect(requestURL).To(BeNil())
+			})
+
+			It("responds with the given version", func() {
+				Expect(response).To(Equal(concourse.CheckResponse[xr.Version]{request.Version}))
+			})
+		})
+
+		Context("on the next business day before publication", func() {
+			BeforeEach(func() {
+				now = time.Date(2024, 4, 2, 15, 59, 0, 0, berlin)
+			})
+
+			It("does not query the API", func() {
+				Expect(requestURL).To(BeNil())
+			})
+		})
+
+		Context("on the next business day after publication", func() {
+			BeforeEach(func() {
+				now = time.Date(2024, 4, 2, 16, 1, 0, 0, berlin)
+			})
+
+			It("queries the API", func() {
+				Expect(requestURL).ToNot(BeNil())
+				Expect(requestURL.Path).To(Equal("/2024-03-28.."))
+			})
+
+			It("copes with the upstream not having published yet", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).To(HaveLen(1))
+			})
+		})
+	})
+
+	Context("version with digest given", func() {
+		BeforeEach(func() {
+			thursday, e := frankfurter.NewYMD("2024-03-28")
+			Expect(e).ToNot(HaveOccurred())
+
+			request.Source.Digest = true
+			request.Version = xr.Version{Date: thursday, Digest: sha256Hex("USD=1.08\n")}
+			now = time.Date(2024, 4, 1, 18, 0, 0, 0, berlin)
+		})
+
+		It("queries the API even before the next publication", func() {
+			Expect(requestURL).ToNot(BeNil())
+			Expect(requestURL.Path).To(Equal("/2024-03-28.."))
+		})
+
+		It("detects a correction of the rates", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response).To(HaveLen(1))
+			Expect(response[0].Digest).To(Equal(sha256Hex("USD=1.0811\n")))
+		})
+	})
+
+	Context("no version given", func() {
+		BeforeEach(func() {
+			now = time.Date(2024, 3, 30, 12, 0, 0, 0, berlin)
+			responseBody = `{ "amount": 1.0, "base": "EUR", "date": "2024-03-28", "rates": { "USD": 1.0811 } }`
+		})
+
+		It("always queries the API", func() {
+			Expect(requestURL).ToNot(BeNil())
+			Expect(requestURL.Path).To(Equal("/latest"))
+		})
+	})
+})
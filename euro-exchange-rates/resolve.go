@@ -0,0 +1,81 @@
+package euroexchangerates
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+// Policies for resolving a requested date to a publication date in Get
+const (
+	StrictResolution   = "strict" // default
+	PreviousResolution = "previous"
+	NextResolution     = "next"
+	NearestResolution  = "nearest"
+)
+
+// resolve returns the rates that apply to the requested date according to the policy. The given rates are those
+// Frankfurter returned for the requested date, which are the ones of the last publication on or before it.
+//
+// The strict policy fails if there was no publication on the requested date. This is what Concourse requires, but
+// useless for looking up the rate "valid on" a holiday, which the other policies are for.
+func resolve(ctx context.Context, service frankfurter.ExchangeRatesService, policy string, requested frankfurter.YMD, rates *frankfurter.ExchangeRates, currencies ...frankfurter.Currency) (*frankfurter.ExchangeRates, error) {
+	if requested.Equal(rates.Date) {
+		return rates, nil
+	}
+
+	var previous *frankfurter.ExchangeRates
+
+	if rates.Date.Before(requested) {
+		previous = rates
+	}
+
+	switch policy {
+	case PreviousResolution:
+		if previous == nil {
+			return nil, fmt.Errorf("there is no publication on or before %s", requested)
+		}
+
+		return previous, nil
+	case NextResolution:
+		next, err := adjacentRates(ctx, service, requested, forward, currencies...)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if next == nil {
+			return nil, fmt.Errorf("there is no publication after %s yet", requested)
+		}
+
+		return next, nil
+	case NearestResolution:
+		next, err := adjacentRates(ctx, service, requested, forward, currencies...)
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case previous == nil && next == nil:
+			return nil, fmt.Errorf("there is no publication near %s", requested)
+		case next == nil:
+			return previous, nil
+		case previous == nil:
+			return next, nil
+		case daysApart(next.Date, requested) < daysApart(requested, previous.Date):
+			return next, nil
+		default:
+			return previous, nil // ties go to the previous publication
+		}
+	default:
+		return nil, fmt.Errorf("requested version %s is not available; closest is %s", requested, rates.Date)
+	}
+}
+
+// daysApart returns the number of calendar days from a back to b, including fractions
+func daysApart(a, b frankfurter.YMD) float64 {
+	return time.Time(a).Sub(time.Time(b)).Hours() / 24
+}
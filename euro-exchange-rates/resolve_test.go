@@ -0,0 +1,189 @@
+package euroexchangerates_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/concourse-resource-go"
+	xr "github.com/suhlig/euro-exchange-rates-resource/euro-exchange-rates"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Resolution", func() {
+	var (
+		err      error
+		request  concourse.GetRequest[xr.Source, xr.Version, xr.Params]
+		response *concourse.Response[xr.Version]
+	)
+
+	BeforeEach(func() {
+		request = concourse.GetRequest[xr.Source, xr.Version, xr.Params]{}
+		request.Source.URL = server.URL
+		request.Source.Currencies = []frankfurter.Currency{frankfurter.Currency("USD")}
+
+		responses = map[string]string{
+			// Good Friday; Frankfurter snaps to the day before
+			"/2024-03-29": `{ "amount": 1.0, "base": "EUR", "date": "2024-03-28", "rates": { "USD": 1.0811 } }`,
+			// Easter Monday
+			"/2024-04-01": `{ "amount": 1.0, "base": "EUR", "date": "2024-03-28", "rates": { "USD": 1.0811 } }`,
+			"/2024-03-30..2024-04-03": `
+				{
+					"amount": 1.0,
+					"base": "EUR",
+					"start_date": "2024-04-02",
+					"end_date": "2024-04-03",
+					"rates": {
+						"2024-04-02": { "USD": 1.0759 },
+						"2024-04-03": { "USD": 1.0774 }
+					}
+				}
+			`,
+			"/2024-04-02..2024-04-06": `
+				{
+					"amount": 1.0,
+					"base": "EUR",
+					"start_date": "2024-04-02",
+					"end_date": "2024-04-05",
+					"rates": {
+						"2024-04-02": { "USD": 1.0759 },
+						"2024-04-03": { "USD": 1.0774 }
+					}
+				}
+			`,
+		}
+
+		date, e := frankfurter.NewYMD("2024-03-29")
+		Expect(e).ToNot(HaveOccurred())
+		request.Version = xr.Version{Date: date}
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		response, err = resource.Get(ctx, request, GinkgoWriter, inputDir)
+	})
+
+	effectiveDate := func() string {
+		content, err := os.ReadFile(filepath.Join(inputDir, "effective_date"))
+		Expect(err).ToNot(HaveOccurred())
+
+		return string(content)
+	}
+
+	Context("by default", func() {
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring("requested version 2024-03-29 is not available; closest is 2024-03-28")))
+		})
+	})
+
+	Context("strict", func() {
+		BeforeEach(func() {
+			request.Params.Resolve = xr.StrictResolution
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring("is not available")))
+		})
+	})
+
+	Context("unknown policy", func() {
+		BeforeEach(func() {
+			request.Params.Resolve = "closest"
+		})
+
+		It("is rejected by the request validation", func() {
+			Expect(request.Validate()).To(MatchError(ContainSubstring("Resolve")))
+		})
+	})
+
+	Context("previous", func() {
+		BeforeEach(func() {
+			request.Params.Resolve = xr.PreviousResolution
+		})
+
+		It("works", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("keeps the requested version", func() {
+			Expect(response.Version.Date.String()).To(Equal("2024-03-29"))
+		})
+
+		It("uses the rates of the previous publication", func() {
+			content, err := os.ReadFile(filepath.Join(inputDir, "USD"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(Equal("1.0811"))
+		})
+
+		It("records the effective date", func() {
+			Expect(effectiveDate()).To(Equal("2024-03-28"))
+			Expect(response.Metadata).To(ContainElement(concourse.NameValuePair{Name: "effective_date", Value: "2024-03-28"}))
+		})
+	})
+
+	Context("next", func() {
+		BeforeEach(func() {
+			request.Params.Resolve = xr.NextResolution
+		})
+
+		It("works", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("uses the rates of the next publication", func() {
+			content, err := os.ReadFile(filepath.Join(inputDir, "USD"))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(string(content)).To(Equal("1.0759"))
+		})
+
+		It("records the effective date", func() {
+			Expect(effectiveDate()).To(Equal("2024-04-02"))
+		})
+
+		Context("nothing published yet", func() {
+			BeforeEach(func() {
+				responses["/2024-03-30..2024-04-03"] = `{ "amount": 1.0, "base": "EUR", "start_date": "2024-03-28", "end_date": "2024-03-28", "rates": {} }`
+			})
+
+			It("fails", func() {
+				Expect(err).To(MatchError(ContainSubstring("there is no publication after 2024-03-29 yet")))
+			})
+		})
+	})
+
+	Context("nearest", func() {
+		BeforeEach(func() {
+			request.Params.Resolve = xr.NearestResolution
+		})
+
+		It("prefers the closer previous publication", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(effectiveDate()).To(Equal("2024-03-28"))
+		})
+
+		Context("next publication is closer", func() {
+			BeforeEach(func() {
+				date, e := frankfurter.NewYMD("2024-04-01")
+				Expect(e).ToNot(HaveOccurred())
+				request.Version = xr.Version{Date: date}
+			})
+
+			It("uses the next publication", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(effectiveDate()).To(Equal("2024-04-02"))
+			})
+		})
+	})
+
+	Context("a publication on the requested date", func() {
+		BeforeEach(func() {
+			request.Params.Resolve = xr.NearestResolution
+			responses["/2024-03-29"] = `{ "amount": 1.0, "base": "EUR", "date": "2024-03-29", "rates": { "USD": 1.08 } }`
+		})
+
+		It("uses it", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(effectiveDate()).To(Equal("2024-03-29"))
+		})
+	})
+})
@@ -3,14 +3,16 @@ package euroexchangerates
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path"
-	"sort"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/suhlig/concourse-resource-go"
 	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
@@ -18,60 +20,272 @@ import (
 
 type ConcourseResource[S Source, V Version, P Params] struct {
 	HttpClient *http.Client
+
+	// Clock tells the current time; time.Now is used if nil
+	Clock func() time.Time
 }
 
 type Source struct {
-	URL        string                 `json:"url" validate:"required,http_url"`
-	Currencies []frankfurter.Currency `json:"currencies"`
-	Verbose    bool
+	URL        string                        `json:"url" validate:"required_without=Mirrors,omitempty,http_url"`
+	Currencies []frankfurter.Currency        `json:"currencies"`
+	Bands      map[frankfurter.Currency]Band `json:"bands"`
+	Digest     bool                          `json:"digest"`
+	Gaps       string                        `json:"gaps" validate:"omitempty,oneof=ignore warn fail refetch"`
+
+	// Mirrors serve the same API as URL; they are tried in the order given by MirrorStrategy on connection errors or 5xx responses
+	Mirrors        []string `json:"mirrors" validate:"omitempty,dive,http_url"`
+	MirrorStrategy string   `json:"mirror_strategy" validate:"omitempty,oneof=failover fastest round-robin"`
+
+	// CrossCheck makes Get verify that all mirrors agree on the rates of the requested date
+	CrossCheck bool `json:"cross_check"`
+
+	// Consensus makes Get compare the rates with a second, independent source
+	Consensus *Consensus `json:"consensus" validate:"omitempty"`
+
+	// MaxDailyChange is the largest day-over-day move of a rate (in percent) that is accepted; unlimited if zero
+	MaxDailyChange float64 `json:"max_daily_change" validate:"omitempty,gt=0"`
+
+	// ScopedVersions records base and currency set in each version, so that changing them starts a fresh version history
+	ScopedVersions bool `json:"scoped_versions"`
+	Verbose        bool
 }
 
 type Version struct {
-	Date frankfurter.YMD `json:"date" validate:"required"`
+	Date       frankfurter.YMD      `json:"date" validate:"required"`
+	Digest     string               `json:"digest,omitempty"`
+	Base       frankfurter.Currency `json:"base,omitempty"`
+	Currencies string               `json:"currencies,omitempty"`
 }
 
 func (v Version) String() string {
 	return v.Date.String()
 }
 
-type Params struct{}
+func (s Source) validate() error {
+	return errors.Join(validateBands(s), validateMirrors(s))
+}
+
+// probeCurrency is requested by Check when it only needs to discover dates
+const probeCurrency = frankfurter.Currency("USD")
+
+// checkCurrencies returns the currencies Check needs to request. Unless the actual rates are needed for bands, digests
+// or the maximum daily change, a single currency is enough to discover the dates for which rates exist.
+func (s Source) checkCurrencies() []frankfurter.Currency {
+	switch {
+	case s.Digest, s.MaxDailyChange > 0:
+		return s.Currencies
+	case len(s.Bands) > 0:
+		banded := make([]frankfurter.Currency, 0, len(s.Bands))
+
+		for currency := range s.Bands {
+			banded = append(banded, currency)
+		}
+
+		slices.Sort(banded)
+
+		return banded
+	case len(s.Currencies) > 0:
+		return s.Currencies[:1]
+	default:
+		return []frankfurter.Currency{probeCurrency}
+	}
+}
+
+type Params struct {
+	// Resolve is the policy for dates without a publication: strict (default), previous, next or nearest
+	Resolve string `json:"resolve" validate:"omitempty,oneof=strict previous next nearest"`
+
+	// Currencies narrows the configured currencies for this step
+	Currencies []frankfurter.Currency `json:"currencies"`
+
+	// Base converts the rates to another base currency than EUR
+	Base frankfurter.Currency `json:"base" validate:"omitempty,len=3,uppercase"`
+
+	// Amount multiplies the rates; defaults to 1
+	Amount float32 `json:"amount" validate:"omitempty,gt=0"`
+
+	// Formats lists the documents to write in addition to the per-currency files: json, yaml, csv, env or properties
+	Formats []string `json:"formats" validate:"omitempty,dive,oneof=json yaml csv env properties"`
+
+	// History optionally selects a window of rates preceding the version date to write as a time series
+	History *Window `json:"history" validate:"omitempty"`
+
+	// Stats optionally computes statistics over the history window
+	Stats *Statistics `json:"stats" validate:"omitempty"`
+
+	// Candles optionally resamples the history window to open/high/low/close candles per period: weekly or monthly
+	Candles []frankfurter.Period `json:"candles" validate:"omitempty,dive,oneof=weekly monthly"`
+
+	// Cross optionally writes inverse rates and the cross rates between all currencies
+	Cross *Cross `json:"cross" validate:"omitempty"`
+
+	// Convert optionally converts a list of amounts into every currency
+	Convert *Convert `json:"convert" validate:"omitempty"`
+
+	// Locales optionally lists BCP 47 tags (e.g. de-DE) to write human-readable renditions of rates and conversions for
+	Locales []string `json:"locales"`
+
+	// Templates optionally render files with Go's text/template
+	Templates []Template `json:"templates" validate:"omitempty,dive"`
+
+	// Directory is a subdirectory of the destination to write to
+	Directory string `json:"directory"`
+}
 
 func (r ConcourseResource[S, V, P]) Check(ctx context.Context, request concourse.CheckRequest[Source, Version], log io.Writer) (concourse.CheckResponse[Version], error) {
 	if request.Source.Verbose {
 		r.HttpClient.Transport = RequestResponseLogger{Writer: log}
 	}
 
-	service := frankfurter.ExchangeRatesService{URL: request.Source.URL, HttpClient: r.HttpClient}
+	err := request.Source.validate()
+
+	if err != nil {
+		return nil, err
+	}
+
+	service := r.service(request.Source, log, request.Version.Date)
 
 	var response concourse.CheckResponse[Version]
+	currencies := request.Source.checkCurrencies()
+
+	if request.Source.ScopedVersions && !request.Version.Date.IsZero() && !request.Source.inScope(request.Version) {
+		fmt.Fprintf(log, "Version %s was recorded for base %q and currencies %q, but %q and %q are configured now; starting a fresh version history\n",
+			request.Version, request.Version.Base, request.Version.Currencies, euro, currencySet(request.Source.Currencies))
+		request.Version = Version{}
+	}
 
 	if request.Version.Date.IsZero() {
 		fmt.Fprintf(log, "Fetching latest exchange rates\n")
-		rates, err := service.Latest(ctx, request.Source.Currencies...)
+		rates, err := service.Latest(ctx, currencies...)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch latest rate from %s: %w", request.Source.endpoint(), err)
+		}
+
+		err = checkRatesSanity(request.Source, r.now(), rates, frankfurter.YMD{}, nil)
 
 		if err != nil {
-			return nil, fmt.Errorf("unable to fetch latest rate from %s: %w", request.Source.URL, err)
+			return nil, err
 		}
 
-		response = concourse.CheckResponse[Version]{Version{Date: rates.Date}}
+		warnAboutClosingDays(log, rates.Date)
+
+		version := Version{Date: rates.Date}
+
+		if request.Source.Digest {
+			version.Digest = digest(rates.Rates, request.Source.Currencies)
+		}
+
+		response = concourse.CheckResponse[Version]{request.Source.scoped(version, rates.Base)}
+	} else if next := nextPublication(request.Version.Date); !request.Source.Digest && r.now().Before(time.Time(next)) {
+		// with digests, the rates of the version date need to be fetched again as they may have been corrected since
+		fmt.Fprintf(log, "Not fetching exchange rates; no publication after %s is expected before %s\n", request.Version, time.Time(next).Format(time.RFC3339))
+		response = concourse.CheckResponse[Version]{request.Version}
 	} else {
 		fmt.Fprintf(log, "Fetching exchange rates since %s\n", request.Version)
-		history, err := service.Since(ctx, request.Version.Date, request.Source.Currencies...)
+		response, err = r.versionsSince(ctx, log, service, request, currencies...)
 
 		if err != nil {
-			return nil, fmt.Errorf("unable to fetch rates since %s from %s: %w", request.Version, request.Source.URL, err)
+			return nil, err
+		}
+	}
+
+	return response, nil
+}
+
+// versionsSince streams the rates since the requested version and returns a version for each date. Gaps are handled,
+// the rates are checked for sanity and reduced to band changes on the way, so that only the rates of the preceding
+// date are kept in memory.
+func (r ConcourseResource[S, V, P]) versionsSince(ctx context.Context, log io.Writer, service frankfurter.ExchangeRatesService, request concourse.CheckRequest[Source, Version], currencies ...frankfurter.Currency) (concourse.CheckResponse[Version], error) {
+	source := request.Source
+	gaps := gaps{source: source, log: log}
+	bands := bandTracker{bands: source.Bands}
+	problems := make(map[string][]string)
+
+	var (
+		versions     []Version
+		previousDate frankfurter.YMD
+		previous     frankfurter.Rates
+	)
+
+	// the base is checked once the history is complete, as it is only known then
+	add := func(date frankfurter.YMD, rates frankfurter.Rates) error {
+		if found := insanities(source, r.now(), euro, date, rates, previousDate, previous); len(found) > 0 {
+			problems[date.String()] = found
+		}
+
+		previousDate, previous = date, rates
+		warnAboutClosingDays(log, date)
+
+		if len(source.Bands) > 0 {
+			changed, err := bands.changed(date, rates)
+
+			if err != nil || !changed {
+				return err
+			}
+		}
+
+		version := Version{Date: date}
+
+		if source.Digest {
+			version.Digest = digest(rates, source.Currencies)
+
+			if date.Equal(request.Version.Date) && request.Version.Digest != "" && version.Digest != request.Version.Digest {
+				fmt.Fprintf(log, "Rates as of %s were revised; digest changed from %s to %s\n", date, request.Version.Digest, version.Digest)
+			}
+		}
+
+		versions = append(versions, version)
+
+		return nil
+	}
+
+	// errors of our own are returned as they are, not as failures to fetch
+	var stopped error
+
+	history, err := service.EachSince(ctx, request.Version.Date, func(date frankfurter.YMD, rates frankfurter.Rates) error {
+		recovered, err := gaps.fill(ctx, service, previousDate, date, currencies...)
+
+		for i := 0; err == nil && i < len(recovered); i++ {
+			err = add(recovered[i].Date, recovered[i].Rates)
 		}
 
-		for date := range history.Rates {
-			response = append(response, Version{Date: date})
+		if err == nil {
+			err = add(date, rates)
 		}
 
-		// Cannot use sort.Sort(response) here because
-		// a) [T comparable] is not enough for sorting (needs to be [T cmp.Ordered]), and
-		// b) implementing Less is not possible in a generic way because only certain built-in types satisfy https://pkg.go.dev/cmp@master#Ordered
-		sort.Slice(response, func(i, j int) bool {
-			return response[i].Date.Before(response[j].Date)
-		})
+		stopped = err
+
+		return err
+	}, currencies...)
+
+	if stopped != nil {
+		return nil, stopped
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch rates since %s from %s: %w", request.Version, source.endpoint(), err)
+	}
+
+	err = gaps.done()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if history.Base != euro && len(versions) > 0 {
+		first := versions[0].Date.String()
+		problems[first] = append([]string{fmt.Sprintf("base is %s instead of %s", history.Base, euro)}, problems[first]...)
+	}
+
+	if len(problems) > 0 {
+		return nil, sanityError{problems}
+	}
+
+	response := make(concourse.CheckResponse[Version], len(versions))
+
+	for i, version := range versions {
+		response[i] = source.scoped(version, history.Base)
 	}
 
 	return response, nil
@@ -82,23 +296,78 @@ func (r ConcourseResource[S, V, P]) Get(ctx context.Context, request concourse.G
 		r.HttpClient.Transport = RequestResponseLogger{Writer: log}
 	}
 
+	err := errors.Join(request.Source.validate(), request.Params.validate(request.Source))
+
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Version.Currencies != "" && request.Version.Currencies != currencySet(request.Source.Currencies) {
+		return nil, fmt.Errorf("version %s was recorded for currencies %s, but %s are configured", request.Version, request.Version.Currencies, currencySet(request.Source.Currencies))
+	}
+
 	if len(request.Source.Currencies) == 0 {
 		fmt.Fprintf(log, "Fetching all exchange rates as of %s and placing them in %s\n", request.Version, destination)
 	} else {
 		fmt.Fprintf(log, "Fetching exchange rates for %s as of %s and placing them in %s\n", request.Source.Currencies, request.Version, destination)
 	}
 
-	rates, err := frankfurter.ExchangeRatesService{
-		URL:        request.Source.URL,
-		HttpClient: r.HttpClient,
-	}.At(ctx, request.Version.Date, request.Source.Currencies...)
+	if request.Params.Directory != "" {
+		destination = path.Join(destination, request.Params.Directory)
+		err = os.MkdirAll(destination, 0755)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to create output directory: %w", err)
+		}
+	}
+
+	service := r.service(request.Source, log, request.Version.Date)
+	rates, err := service.At(ctx, request.Version.Date, request.Source.Currencies...)
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to fetch rates as of %s from %s: %w", request.Version.Date, request.Source.URL, err)
+		return nil, fmt.Errorf("unable to fetch rates as of %s from %s: %w", request.Version.Date, request.Source.endpoint(), err)
 	}
 
-	if !request.Version.Date.Equal(rates.Date) {
-		return nil, fmt.Errorf("requested version %s is not available; closest is %s", request.Version.Date, rates.Date)
+	rates, err = resolve(ctx, service, request.Params.Resolve, request.Version.Date, rates, request.Source.Currencies...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !rates.Date.Equal(request.Version.Date) {
+		fmt.Fprintf(log, "No rates were published on %s; using those of %s\n", request.Version.Date, rates.Date)
+	}
+
+	previous := &frankfurter.ExchangeRates{}
+
+	if request.Source.MaxDailyChange > 0 {
+		preceding, err := adjacentRates(ctx, service, rates.Date, backward, request.Source.Currencies...)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if preceding != nil {
+			previous = preceding
+		}
+	}
+
+	err = checkRatesSanity(request.Source, r.now(), rates, previous.Date, previous.Rates)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Source.CrossCheck {
+		err = r.crossCheck(ctx, log, request.Source, rates)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if request.Version.Base != "" && request.Version.Base != rates.Base {
+		return nil, fmt.Errorf("version %s was recorded for base %s, but rates are based on %s", request.Version, request.Version.Base, rates.Base)
 	}
 
 	for _, c := range request.Source.Currencies {
@@ -109,21 +378,192 @@ func (r ConcourseResource[S, V, P]) Get(ctx context.Context, request concourse.G
 		}
 	}
 
-	for currency, rate := range rates.Rates {
+	if request.Version.Digest != "" {
+		actual := digest(rates.Rates, request.Source.Currencies)
+
+		if actual != request.Version.Digest {
+			return nil, fmt.Errorf("rates as of %s do not match the requested version; expected digest %s, but got %s", request.Version.Date, request.Version.Digest, actual)
+		}
+	}
+
+	if request.Source.Consensus != nil {
+		reconciliation, err := r.reconcile(ctx, log, request.Source, rates)
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = writeReconciliation(destination, reconciliation)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to write reconciliation report: %w", err)
+		}
+
+		err = reconciliation.disagreement()
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	output, err := outputRates(ctx, service, request.Params, request.Source, rates)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for currency, rate := range output.Rates {
 		os.WriteFile(path.Join(destination, string(currency)), []byte(rateString(rate)), 0755)
 	}
 
+	err = writeFormats(destination, request.Params.Formats, output)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to write rates: %w", err)
+	}
+
+	if request.Params.Cross != nil {
+		cross, err := newCrossRates(output, *request.Params.Cross)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to derive cross rates: %w", err)
+		}
+
+		if request.Params.Cross.Inverse {
+			err = writeInverse(destination, cross)
+
+			if err != nil {
+				return nil, fmt.Errorf("unable to write inverse rates: %w", err)
+			}
+		}
+
+		if request.Params.Cross.Matrix {
+			err = writeMatrix(destination, cross)
+
+			if err != nil {
+				return nil, fmt.Errorf("unable to write cross rates: %w", err)
+			}
+		}
+	}
+
+	var conversions *conversionTable
+
+	if request.Params.Convert != nil {
+		table, err := newConversionTable(output, *request.Params.Convert)
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = writeConversions(destination, table)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to write conversions: %w", err)
+		}
+
+		conversions = &table
+	}
+
+	err = writeFormatted(destination, request.Params.Locales, output, conversions)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to write formatted rates: %w", err)
+	}
+
+	err = writeTemplates(destination, request.Params.Templates, output)
+
+	if err != nil {
+		return nil, err
+	}
+
 	response := concourse.Response[Version]{
-		Version: Version{Date: request.Version.Date},
+		Version: request.Version,
+	}
+
+	for c := range output.Rates {
+		response.Metadata = append(response.Metadata, concourse.NameValuePair{Name: string(c), Value: rateString(output.Rates[c])})
+	}
+
+	if request.Params.History != nil {
+		history, start, err := windowHistory(ctx, service, request.Params, request.Source, rates.Date)
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = writeHistory(destination, series(history, start, rates.Date, request.Params.History.FillForward))
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to write history: %w", err)
+		}
+
+		if len(request.Params.Candles) > 0 {
+			window := *history
+			window.Start, window.End = start, rates.Date
+
+			err = writeCandles(destination, window, request.Params.Candles)
+
+			if err != nil {
+				return nil, fmt.Errorf("unable to write candles: %w", err)
+			}
+		}
+
+		if request.Params.Stats != nil {
+			stats, err := newStatistics(history, *request.Params.Stats)
+
+			if err != nil {
+				return nil, fmt.Errorf("unable to compute statistics: %w", err)
+			}
+
+			err = writeStatistics(destination, stats)
+
+			if err != nil {
+				return nil, fmt.Errorf("unable to write statistics: %w", err)
+			}
+
+			response.Metadata = append(response.Metadata, stats.metadata()...)
+		}
 	}
 
-	for c := range rates.Rates {
-		response.Metadata = append(response.Metadata, concourse.NameValuePair{Name: string(c), Value: rateString(rates.Rates[c])})
+	if request.Params.Resolve != "" && request.Params.Resolve != StrictResolution {
+		err = os.WriteFile(path.Join(destination, "effective_date"), []byte(rates.Date.String()), 0644)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to write effective date: %w", err)
+		}
+
+		response.Metadata = append(response.Metadata, concourse.NameValuePair{Name: "effective_date", Value: rates.Date.String()})
+	}
+
+	if len(request.Source.Bands) > 0 {
+		reports, err := bandReports(ctx, service, request.Source.Bands, rates)
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = writeBandReports(destination, reports)
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to write band reports: %w", err)
+		}
+
+		for _, report := range reports {
+			response.Metadata = append(response.Metadata, concourse.NameValuePair{Name: string(report.Currency) + " band", Value: report.String()})
+		}
 	}
 
 	return &response, nil
 }
 
+func (r ConcourseResource[S, V, P]) now() time.Time {
+	if r.Clock == nil {
+		return time.Now()
+	}
+
+	return r.Clock()
+}
+
 func (r ConcourseResource[S, V, P]) Put(ctx context.Context, request concourse.PutRequest[Source, Params], log io.Writer, source string) (*concourse.Response[Version], error) {
 	fmt.Fprintf(log, "This resource does nothing on put\n")
 	return &concourse.Response[Version]{}, nil
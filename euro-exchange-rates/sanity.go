@@ -0,0 +1,101 @@
+package euroexchangerates
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/suhlig/euro-exchange-rates-resource/calendar"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+// insanities checks the rates as of the given date and returns a description of each problem found: The base must
+// be the expected one, the date must not be in the future, and all rates must be positive and finite. If the rates of
+// the previous publication are passed and a maximum daily change is configured, no rate may move more than that.
+func insanities(source Source, now time.Time, base frankfurter.Currency, date frankfurter.YMD, rates frankfurter.Rates, previousDate frankfurter.YMD, previous frankfurter.Rates) []string {
+	var problems []string
+
+	if base != euro {
+		problems = append(problems, fmt.Sprintf("base is %s instead of %s", base, euro))
+	}
+
+	if today := now.In(calendar.Frankfurt).Format(time.DateOnly); date.String() > today {
+		problems = append(problems, fmt.Sprintf("date %s is in the future (today is %s)", date, today))
+	}
+
+	currencies := make([]frankfurter.Currency, 0, len(rates))
+
+	for currency := range rates {
+		currencies = append(currencies, currency)
+	}
+
+	slices.Sort(currencies)
+
+	for _, currency := range currencies {
+		rate := rates[currency]
+
+		if math.IsNaN(float64(rate)) || math.IsInf(float64(rate), 0) {
+			problems = append(problems, fmt.Sprintf("%s: rate %v is not finite", currency, rate))
+			continue
+		}
+
+		if rate <= 0 {
+			problems = append(problems, fmt.Sprintf("%s: rate %s is not positive", currency, rateString(rate)))
+			continue
+		}
+
+		previousRate, found := previous[currency]
+
+		if source.MaxDailyChange == 0 || !found || previousRate <= 0 {
+			continue
+		}
+
+		change := difference(rate, previousRate) / float64(previousRate) * 100
+
+		if math.Abs(change) > source.MaxDailyChange {
+			problems = append(problems, fmt.Sprintf("%s: rate changed by %s%% from %s on %s to %s (maximum is %s%%)",
+				currency, strconv.FormatFloat(change, 'f', 2, 64), rateString(previousRate), previousDate, rateString(rate), strconv.FormatFloat(source.MaxDailyChange, 'f', -1, 64)))
+		}
+	}
+
+	return problems
+}
+
+// sanityError reports all problems found with the rates of one or more dates
+type sanityError struct {
+	problems map[string][]string // by date
+}
+
+func (e sanityError) Error() string {
+	dates := make([]string, 0, len(e.problems))
+
+	for date := range e.problems {
+		dates = append(dates, date)
+	}
+
+	slices.Sort(dates)
+
+	var report strings.Builder
+
+	report.WriteString("rates failed the sanity check:")
+
+	for _, date := range dates {
+		for _, problem := range e.problems[date] {
+			fmt.Fprintf(&report, "\n%s: %s", date, problem)
+		}
+	}
+
+	return report.String()
+}
+
+// checkRatesSanity checks the rates of a single date against the given previous ones
+func checkRatesSanity(source Source, now time.Time, rates *frankfurter.ExchangeRates, previousDate frankfurter.YMD, previous frankfurter.Rates) error {
+	if found := insanities(source, now, rates.Base, rates.Date, rates.Rates, previousDate, previous); len(found) > 0 {
+		return sanityError{map[string][]string{rates.Date.String(): found}}
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package euroexchangerates_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/concourse-resource-go"
+	xr "github.com/suhlig/euro-exchange-rates-resource/euro-exchange-rates"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Sanity guard", func() {
+	var midJanuary frankfurter.YMD
+
+	BeforeEach(func() {
+		var e error
+		midJanuary, e = frankfurter.NewYMD("2024-01-15")
+		Expect(e).ToNot(HaveOccurred())
+	})
+
+	Describe("Check", func() {
+		var (
+			err     error
+			request concourse.CheckRequest[xr.Source, xr.Version]
+		)
+
+		BeforeEach(func() {
+			request = concourse.CheckRequest[xr.Source, xr.Version]{}
+			request.Source.URL = server.URL
+			request.Version = xr.Version{Date: midJanuary}
+			request.Source.MaxDailyChange = 5
+
+			responseBody = `
+				{
+					"amount": 1.0,
+					"base": "EUR",
+					"start_date": "2024-01-15",
+					"end_date": "2024-01-17",
+					"rates": {
+						"2024-01-15": { "SEK": 11.3215, "USD": 1.0887 },
+						"2024-01-16": { "SEK": 11.33, "USD": 1.089 },
+						"2024-01-17": { "SEK": 11.3412, "USD": 1.0872 }
+					}
+				}
+			`
+		})
+
+		JustBeforeEach(func(ctx SpecContext) {
+			_, err = resource.Check(ctx, request, GinkgoWriter)
+		})
+
+		It("accepts sane rates", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("rate jumps", func() {
+			BeforeEach(func() {
+				responseBody = `
+					{
+						"amount": 1.0,
+						"base": "EUR",
+						"start_date": "2024-01-15",
+						"end_date": "2024-01-17",
+						"rates": {
+							"2024-01-15": { "SEK": 11.3215, "USD": 1.0887 },
+							"2024-01-16": { "SEK": 11.33, "USD": 1.2 },
+							"2024-01-17": { "SEK": 0, "USD": 1.0872 }
+						}
+					}
+				`
+			})
+
+			It("reports every offending currency", func() {
+				Expect(err).To(MatchError(And(
+					ContainSubstring("2024-01-16: USD: rate changed by 10.22% from 1.0887 on 2024-01-15 to 1.2 (maximum is 5%)"),
+					ContainSubstring("2024-01-17: USD: rate changed by -9.40%"),
+					ContainSubstring("2024-01-17: SEK: rate 0 is not positive"),
+				)))
+			})
+		})
+		Context("a negative maximum daily change", func() {
+			BeforeEach(func() {
+				request.Source.MaxDailyChange = -5
+			})
+
+			It("is rejected by the request validation", func() {
+				Expect(request.Validate()).To(MatchError(ContainSubstring("MaxDailyChange")))
+			})
+		})
+	})
+
+	Describe("Get", func() {
+		var (
+			err     error
+			request concourse.GetRequest[xr.Source, xr.Version, xr.Params]
+		)
+
+		BeforeEach(func() {
+			request = concourse.GetRequest[xr.Source, xr.Version, xr.Params]{}
+			request.Source.URL = server.URL
+			request.Version = xr.Version{Date: midJanuary}
+
+			responseBody = `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "SEK": 11.3215, "USD": 1.0882 } }`
+		})
+
+		JustBeforeEach(func(ctx SpecContext) {
+			_, err = resource.Get(ctx, request, GinkgoWriter, GinkgoT().TempDir())
+		})
+
+		Context("negative rate", func() {
+			BeforeEach(func() {
+				responseBody = `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "SEK": 11.3215, "USD": -1.0882 } }`
+			})
+
+			It("fails", func() {
+				Expect(err).To(MatchError(ContainSubstring("USD: rate -1.0882 is not positive")))
+			})
+		})
+
+		Context("wrong base", func() {
+			BeforeEach(func() {
+				responseBody = `{ "amount": 1.0, "base": "USD", "date": "2024-01-15", "rates": { "SEK": 10.4 } }`
+			})
+
+			It("fails", func() {
+				Expect(err).To(MatchError(ContainSubstring("base is USD instead of EUR")))
+			})
+		})
+
+		Context("maximum daily change configured", func() {
+			BeforeEach(func() {
+				request.Source.MaxDailyChange = 1
+
+				responses = map[string]string{
+					"/2024-01-15": responseBody,
+					"/2024-01-10..2024-01-14": `
+						{
+							"amount": 1.0,
+							"base": "EUR",
+							"start_date": "2024-01-10",
+							"end_date": "2024-01-12",
+							"rates": {
+								"2024-01-11": { "SEK": 11.2, "USD": 1.0977 },
+								"2024-01-12": { "SEK": 11.2, "USD": 1.0969 }
+							}
+						}
+					`,
+				}
+			})
+
+			It("compares with the previous publication", func() {
+				Expect(err).To(MatchError(ContainSubstring("SEK: rate changed by 1.08% from 11.2 on 2024-01-12")))
+			})
+		})
+	})
+})
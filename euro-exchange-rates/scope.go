@@ -0,0 +1,41 @@
+package euroexchangerates
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+// euro is the base currency of all rates published by the ECB
+const euro = frankfurter.Currency("EUR")
+
+// allCurrencies represents an empty list of configured currencies in a scoped version
+const allCurrencies = "*"
+
+// currencySet is the canonical representation of a list of currencies as it is recorded in scoped versions.
+func currencySet(currencies []frankfurter.Currency) string {
+	if len(currencies) == 0 {
+		return allCurrencies
+	}
+
+	codes := currencyCodes(currencies)
+	slices.Sort(codes)
+
+	return strings.Join(slices.Compact(codes), ",")
+}
+
+// scoped records base and currency set in the version if the source asks for scoped versions.
+func (s Source) scoped(v Version, base frankfurter.Currency) Version {
+	if s.ScopedVersions {
+		v.Base = base
+		v.Currencies = currencySet(s.Currencies)
+	}
+
+	return v
+}
+
+// inScope tells whether the version was recorded with the base and currency set that are configured now.
+func (s Source) inScope(v Version) bool {
+	return v.Base == euro && v.Currencies == currencySet(s.Currencies)
+}
@@ -0,0 +1,150 @@
+package euroexchangerates_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/concourse-resource-go"
+	xr "github.com/suhlig/euro-exchange-rates-resource/euro-exchange-rates"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Scoped versions", func() {
+	var midJanuary frankfurter.YMD
+
+	BeforeEach(func() {
+		var e error
+		midJanuary, e = frankfurter.NewYMD("2024-01-15")
+		Expect(e).ToNot(HaveOccurred())
+	})
+
+	Describe("Check", func() {
+		var (
+			err      error
+			request  concourse.CheckRequest[xr.Source, xr.Version]
+			response concourse.CheckResponse[xr.Version]
+		)
+
+		BeforeEach(func() {
+			request = concourse.CheckRequest[xr.Source, xr.Version]{}
+			request.Source.URL = server.URL
+			request.Source.ScopedVersions = true
+			request.Source.Currencies = []frankfurter.Currency{
+				frankfurter.Currency("USD"),
+				frankfurter.Currency("SEK"),
+			}
+
+			responses = map[string]string{
+				"/latest": `
+					{
+						"amount": 1.0,
+						"base": "EUR",
+						"date": "2024-01-16",
+						"rates": { "SEK": 11.33, "USD": 1.089 }
+					}
+				`,
+				"/2024-01-15..": `
+					{
+						"amount": 1.0,
+						"base": "EUR",
+						"start_date": "2024-01-15",
+						"end_date": "2024-01-16",
+						"rates": {
+							"2024-01-15": { "SEK": 11.3215, "USD": 1.0887 },
+							"2024-01-16": { "SEK": 11.33, "USD": 1.089 }
+						}
+					}
+				`,
+			}
+		})
+
+		JustBeforeEach(func(ctx SpecContext) {
+			response, err = resource.Check(ctx, request, GinkgoWriter)
+		})
+
+		Context("no version given", func() {
+			It("records base and currency set in the version", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(response).To(HaveLen(1))
+				Expect(response[0].Base).To(Equal(frankfurter.Currency("EUR")))
+				Expect(response[0].Currencies).To(Equal("SEK,USD"))
+			})
+		})
+
+		Context("version of the same scope given", func() {
+			BeforeEach(func() {
+				request.Version = xr.Version{Date: midJanuary, Base: "EUR", Currencies: "SEK,USD"}
+			})
+
+			It("continues the version history", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(requestURL.Path).To(Equal("/2024-01-15.."))
+				Expect(response).To(HaveLen(2))
+				Expect(response[0]).To(Equal(request.Version))
+			})
+		})
+
+		Context("version of a different currency set given", func() {
+			BeforeEach(func() {
+				request.Version = xr.Version{Date: midJanuary, Base: "EUR", Currencies: "USD"}
+			})
+
+			It("starts a fresh version history", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(requestURL.Path).To(Equal("/latest"))
+				Expect(response).To(HaveLen(1))
+				Expect(response[0].Currencies).To(Equal("SEK,USD"))
+			})
+		})
+	})
+
+	Describe("Get", func() {
+		var (
+			err     error
+			request concourse.GetRequest[xr.Source, xr.Version, xr.Params]
+		)
+
+		BeforeEach(func() {
+			request = concourse.GetRequest[xr.Source, xr.Version, xr.Params]{}
+			request.Source.URL = server.URL
+			request.Source.Currencies = []frankfurter.Currency{frankfurter.Currency("SEK")}
+			request.Version = xr.Version{Date: midJanuary, Base: "EUR", Currencies: "SEK"}
+
+			responseBody = `
+				{
+					"amount": 1.0,
+					"base": "EUR",
+					"date": "2024-01-15",
+					"rates": { "SEK": 11.3215 }
+				}
+			`
+		})
+
+		JustBeforeEach(func(ctx SpecContext) {
+			_, err = resource.Get(ctx, request, GinkgoWriter, GinkgoT().TempDir())
+		})
+
+		It("works", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("currency set changed", func() {
+			BeforeEach(func() {
+				request.Source.Currencies = append(request.Source.Currencies, frankfurter.Currency("USD"))
+			})
+
+			It("fails", func() {
+				Expect(err).To(MatchError(ContainSubstring("was recorded for currencies SEK")))
+			})
+		})
+
+		Context("base differs", func() {
+			BeforeEach(func() {
+				request.Version.Base = "USD"
+			})
+
+			It("fails", func() {
+				Expect(err).To(MatchError(ContainSubstring("was recorded for base USD")))
+			})
+		})
+	})
+})
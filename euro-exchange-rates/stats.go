@@ -0,0 +1,206 @@
+package euroexchangerates
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"slices"
+
+	"github.com/suhlig/concourse-resource-go"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+// Statistics configures the statistics Get computes over the history window. Both settings count publications, not
+// calendar days; the default of five is about a week.
+type Statistics struct {
+	Average int `json:"average" validate:"omitempty,gt=0"` // number of publications for the moving averages
+	Period  int `json:"period" validate:"omitempty,gt=0"`  // number of publications to look back for the period change
+}
+
+const defaultStatisticsSpan = 5
+
+func validateStatistics(p Params) error {
+	if p.Stats == nil {
+		return nil
+	}
+
+	if p.History == nil {
+		return errors.New("stats need a history window")
+	}
+
+	return nil
+}
+
+func (s Statistics) average() int {
+	if s.Average <= 0 {
+		return defaultStatisticsSpan
+	}
+
+	return s.Average
+}
+
+func (s Statistics) period() int {
+	if s.Period <= 0 {
+		return defaultStatisticsSpan
+	}
+
+	return s.Period
+}
+
+type change struct {
+	Since    frankfurter.YMD `json:"since"`
+	Absolute float64         `json:"absolute"`
+	Percent  float64         `json:"percent"`
+}
+
+func newChange(since frankfurter.YMD, from, to float32) *change {
+	return &change{Since: since, Absolute: difference(to, from), Percent: round(difference(to, from) / decimal(from) * 100)}
+}
+
+func (c change) String() string {
+	return fmt.Sprintf("%+g (%+.2f%%) since %s", c.Absolute, c.Percent, c.Since)
+}
+
+type currencyStatistics struct {
+	Currency     frankfurter.Currency `json:"currency"`
+	Observations int                  `json:"observations"`
+	Min          float64              `json:"min"`
+	Max          float64              `json:"max"`
+	Mean         float64              `json:"mean"`
+	Median       float64              `json:"median"`
+	StdDev       float64              `json:"stddev"` // of the population
+	SMA          float64              `json:"sma"`
+	EMA          float64              `json:"ema"`
+	Daily        *change              `json:"daily,omitempty"`  // compared to the previous publication
+	Period       *change              `json:"period,omitempty"` // compared to the publication the period ago
+}
+
+type statistics struct {
+	From       frankfurter.YMD      `json:"from"`
+	To         frankfurter.YMD      `json:"to"`
+	Average    int                  `json:"average"`
+	Period     int                  `json:"period"`
+	Currencies []currencyStatistics `json:"currencies"`
+}
+
+// newStatistics computes the statistics of each currency over the publications of the history. Days without a
+// publication are not taken into account, even if the history is filled forward when written. Changes are relative
+// to earlier rates, so it fails if any rate is not a positive number.
+func newStatistics(history *frankfurter.History, config Statistics) (statistics, error) {
+	dates := history.Dates()
+	stats := statistics{Average: config.average(), Period: config.period()}
+
+	if len(dates) == 0 {
+		return stats, nil
+	}
+
+	stats.From, stats.To = dates[0], dates[len(dates)-1]
+	values := make(map[frankfurter.Currency][]float32)
+	observed := make(map[frankfurter.Currency][]frankfurter.YMD)
+
+	for _, date := range dates {
+		for currency, rate := range history.Rates[date] {
+			if !(rate > 0) || math.IsInf(float64(rate), 0) {
+				return statistics{}, fmt.Errorf("%s: rate %s on %s is not a positive number", currency, rateString(rate), date)
+			}
+
+			values[currency] = append(values[currency], rate)
+			observed[currency] = append(observed[currency], date)
+		}
+	}
+
+	currencies := make([]frankfurter.Currency, 0, len(values))
+
+	for currency := range values {
+		currencies = append(currencies, currency)
+	}
+
+	slices.Sort(currencies)
+
+	for _, currency := range currencies {
+		stats.Currencies = append(stats.Currencies, newCurrencyStatistics(currency, observed[currency], values[currency], stats.Average, stats.Period))
+	}
+
+	return stats, nil
+}
+
+func newCurrencyStatistics(currency frankfurter.Currency, dates []frankfurter.YMD, rates []float32, average, period int) currencyStatistics {
+	values := make([]float64, len(rates))
+
+	for i, rate := range rates {
+		values[i] = decimal(rate)
+	}
+
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	n := len(values)
+	s := currencyStatistics{Currency: currency, Observations: n, Min: sorted[0], Max: sorted[n-1]}
+
+	if n%2 == 1 {
+		s.Median = sorted[n/2]
+	} else {
+		s.Median = round((sorted[n/2-1] + sorted[n/2]) / 2)
+	}
+
+	mean := sum(values) / float64(n)
+	s.Mean = round(mean)
+
+	var variance float64
+
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+
+	s.StdDev = round(math.Sqrt(variance / float64(n)))
+
+	recent := values[max(0, n-average):]
+	s.SMA = round(sum(recent) / float64(len(recent)))
+
+	alpha := 2 / float64(average+1)
+	ema := values[0]
+
+	for _, v := range values[1:] {
+		ema = alpha*v + (1-alpha)*ema
+	}
+
+	s.EMA = round(ema)
+
+	if n > 1 {
+		s.Daily = newChange(dates[n-2], rates[n-2], rates[n-1])
+		back := max(0, n-1-period)
+		s.Period = newChange(dates[back], rates[back], rates[n-1])
+	}
+
+	return s
+}
+
+// metadata describes the changes of each currency, e.g. "USD daily change: +0.0043 (+0.40%) since 2024-01-12"
+func (s statistics) metadata() []concourse.NameValuePair {
+	var metadata []concourse.NameValuePair
+
+	for _, c := range s.Currencies {
+		if c.Daily != nil {
+			metadata = append(metadata, concourse.NameValuePair{Name: string(c.Currency) + " daily change", Value: c.Daily.String()})
+		}
+
+		if c.Period != nil {
+			metadata = append(metadata, concourse.NameValuePair{Name: string(c.Currency) + " period change", Value: c.Period.String()})
+		}
+	}
+
+	return metadata
+}
+
+func writeStatistics(destination string, stats statistics) error {
+	content, err := json.MarshalIndent(stats, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path.Join(destination, "stats.json"), content, 0644)
+}
@@ -0,0 +1,146 @@
+package euroexchangerates_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/concourse-resource-go"
+	xr "github.com/suhlig/euro-exchange-rates-resource/euro-exchange-rates"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Statistics", func() {
+	var (
+		err      error
+		request  concourse.GetRequest[xr.Source, xr.Version, xr.Params]
+		response *concourse.Response[xr.Version]
+	)
+
+	BeforeEach(func() {
+		request = concourse.GetRequest[xr.Source, xr.Version, xr.Params]{}
+		request.Source.URL = server.URL
+		request.Source.Currencies = []frankfurter.Currency{frankfurter.Currency("USD")}
+		request.Params.History = &xr.Window{Days: 3}
+		request.Params.Stats = &xr.Statistics{Average: 2}
+
+		date, e := frankfurter.NewYMD("2024-01-15")
+		Expect(e).ToNot(HaveOccurred())
+		request.Version = xr.Version{Date: date}
+
+		responses = map[string]string{
+			"/2024-01-15": `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "USD": 1.0882 } }`,
+			"/2024-01-11..2024-01-15": `
+				{
+					"amount": 1.0,
+					"base": "EUR",
+					"start_date": "2024-01-11",
+					"end_date": "2024-01-15",
+					"rates": {
+						"2024-01-11": { "USD": 1.0987 },
+						"2024-01-12": { "USD": 1.0942 },
+						"2024-01-15": { "USD": 1.0882 }
+					}
+				}
+			`,
+		}
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		response, err = resource.Get(ctx, request, GinkgoWriter, inputDir)
+	})
+
+	It("works", func() {
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("writes stats.json", func() {
+		content, err := os.ReadFile(filepath.Join(inputDir, "stats.json"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(content).To(MatchJSON(`
+			{
+				"from": "2024-01-11",
+				"to": "2024-01-15",
+				"average": 2,
+				"period": 5,
+				"currencies": [
+					{
+						"currency": "USD",
+						"observations": 3,
+						"min": 1.0882,
+						"max": 1.0987,
+						"mean": 1.0937,
+						"median": 1.0942,
+						"stddev": 0.00430116,
+						"sma": 1.0912,
+						"ema": 1.0907,
+						"daily": { "since": "2024-01-12", "absolute": -0.006, "percent": -0.54834582 },
+						"period": { "since": "2024-01-11", "absolute": -0.0105, "percent": -0.95567489 }
+					}
+				]
+			}
+		`))
+	})
+
+	It("describes the changes in the metadata", func() {
+		Expect(response.Metadata).To(ContainElements(
+			concourse.NameValuePair{Name: "USD daily change", Value: "-0.006 (-0.55%) since 2024-01-12"},
+			concourse.NameValuePair{Name: "USD period change", Value: "-0.0105 (-0.96%) since 2024-01-11"},
+		))
+	})
+
+	Context("a single publication", func() {
+		BeforeEach(func() {
+			request.Params.History.Days = 1
+			responses["/2024-01-15..2024-01-15"] = `{ "amount": 1.0, "base": "EUR", "start_date": "2024-01-15", "end_date": "2024-01-15", "rates": { "2024-01-15": { "USD": 1.0882 } } }`
+		})
+
+		It("has no changes", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(response.Metadata).ToNot(ContainElement(HaveField("Name", "USD daily change")))
+		})
+	})
+
+	Context("a zero rate in the window", func() {
+		BeforeEach(func() {
+			responses["/2024-01-11..2024-01-15"] = `
+				{
+					"amount": 1.0,
+					"base": "EUR",
+					"start_date": "2024-01-11",
+					"end_date": "2024-01-15",
+					"rates": {
+						"2024-01-11": { "USD": 1.0987 },
+						"2024-01-12": { "USD": 0 },
+						"2024-01-15": { "USD": 1.0882 }
+					}
+				}
+			`
+		})
+
+		It("reports the currency and date", func() {
+			Expect(err).To(MatchError(ContainSubstring("USD: rate 0 on 2024-01-12 is not a positive number")))
+		})
+	})
+
+	Context("without a history window", func() {
+		BeforeEach(func() {
+			request.Params.History = nil
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring("stats need a history window")))
+		})
+	})
+	Context("a negative period", func() {
+		BeforeEach(func() {
+			request.Params.Stats.Period = -1
+		})
+
+		It("is rejected by the request validation", func() {
+			Expect(request.Validate()).To(MatchError(ContainSubstring("Stats.Period")))
+		})
+	})
+})
@@ -0,0 +1,255 @@
+package euroexchangerates
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"text/template"
+
+	"github.com/suhlig/euro-exchange-rates-resource/currency"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+// Template renders a file from the rates with Go's text/template. It is either given inline, or refers to one of the
+// built-in templates.
+type Template struct {
+	File     string `json:"file" validate:"required"` // relative to the destination
+	Template string `json:"template"`
+	Builtin  string `json:"builtin" validate:"omitempty,oneof=markdown shell"`
+}
+
+var builtinTemplates = map[string]string{
+	"markdown": `# Euro exchange rates as of {{ .Date }}
+
+| Currency | {{ .Amount }} {{ .Base }} |
+|----------|-------:|
+{{ range .Currencies }}| {{ . }} | {{ format (index $.Rates .) }} |
+{{ end -}}
+`,
+	"shell": `export RATES_DATE={{ .Date }}
+{{ range .Currencies }}export {{ $.Base }}_{{ . }}={{ format (index $.Rates .) }}
+{{ end -}}
+`,
+}
+
+// templateData is what templates are rendered with. Currencies are plain strings, so that templates can refer to
+// rates like {{ .Rates.USD }}.
+type templateData struct {
+	Date       string
+	Base       string
+	Amount     float32
+	Rates      map[string]float32
+	Currencies []string // sorted
+}
+
+func newTemplateData(rates *frankfurter.ExchangeRates) templateData {
+	data := templateData{
+		Date:       rates.Date.String(),
+		Base:       string(rates.Base),
+		Amount:     rates.Amount,
+		Rates:      make(map[string]float32, len(rates.Rates)),
+		Currencies: currencyCodes(sortedCurrencies(rates.Rates)),
+	}
+
+	if data.Amount == 0 {
+		data.Amount = 1
+	}
+
+	for c, rate := range rates.Rates {
+		data.Rates[string(c)] = rate
+	}
+
+	return data
+}
+
+func validateTemplates(templates []Template) error {
+	var errs []error
+
+	for _, t := range templates {
+		if !filepath.IsLocal(t.File) {
+			errs = append(errs, fmt.Errorf("template file %s must be relative and must not leave the destination", t.File))
+		}
+
+		_, err := t.parse(&frankfurter.ExchangeRates{})
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (t Template) parse(rates *frankfurter.ExchangeRates) (*template.Template, error) {
+	text := t.Template
+
+	switch {
+	case t.Template != "" && t.Builtin != "":
+		return nil, fmt.Errorf("template for %s is both inline and built-in", t.File)
+	case t.Builtin != "":
+		builtin, found := builtinTemplates[t.Builtin]
+
+		if !found {
+			return nil, fmt.Errorf("unknown built-in template %q; must be markdown or shell", t.Builtin)
+		}
+
+		text = builtin
+	case t.Template == "":
+		return nil, fmt.Errorf("template for %s is neither inline nor built-in", t.File)
+	}
+
+	parsed, err := template.New(t.File).Option("missingkey=error").Funcs(templateFuncs(rates)).Parse(text)
+
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse template for %s: %w", t.File, err)
+	}
+
+	return parsed, nil
+}
+
+// templateFuncs are the helpers available to templates:
+//
+//	format NUMBER [LOCALE]  the number as published, optionally in the way of a locale
+//	round NUMBER DECIMALS   the number rounded half away from zero
+//	invert NUMBER           1 divided by the number
+//	convert AMOUNT FROM TO  the amount converted between two of the currencies (including the base)
+func templateFuncs(rates *frankfurter.ExchangeRates) template.FuncMap {
+	return template.FuncMap{
+		"format": func(number any, locale ...string) (string, error) {
+			s, err := decimalString(number)
+
+			if err != nil || len(locale) == 0 {
+				return s, err
+			}
+
+			l, err := currency.ParseLocale(locale[0])
+
+			if err != nil {
+				return "", err
+			}
+
+			return l.FormatNumber(s), nil
+		},
+		"round": func(number any, decimals int) (float64, error) {
+			x, err := exactNumber(number)
+
+			if err != nil {
+				return 0, err
+			}
+
+			rounded, _ := currency.Round(x, decimals, currency.HalfUp).Float64()
+
+			return rounded, nil
+		},
+		"invert": func(number any) (float64, error) {
+			x, err := exactNumber(number)
+
+			if err != nil {
+				return 0, err
+			}
+
+			if x.Sign() == 0 {
+				return 0, errors.New("cannot invert zero")
+			}
+
+			inverse, _ := x.Inv(x).Float64()
+
+			return inverse, nil
+		},
+		"convert": func(amount any, from, to string) (float64, error) {
+			x, err := exactNumber(amount)
+
+			if err != nil {
+				return 0, err
+			}
+
+			perBase := exactUnitRates(rates)
+			f, found := perBase[frankfurter.Currency(from)]
+
+			if !found {
+				return 0, fmt.Errorf("cannot convert from %s, as it is not among the currencies", from)
+			}
+
+			t, found := perBase[frankfurter.Currency(to)]
+
+			if !found {
+				return 0, fmt.Errorf("cannot convert to %s, as it is not among the currencies", to)
+			}
+
+			converted, _ := x.Mul(x, t).Quo(x, f).Float64()
+
+			return converted, nil
+		},
+	}
+}
+
+// decimalString writes a number the shortest way that reads back the same, e.g. a rate as it was published
+func decimalString(number any) (string, error) {
+	switch n := number.(type) {
+	case float32:
+		return rateString(n), nil
+	case float64:
+		return strconv.FormatFloat(n, 'f', -1, 64), nil
+	case int:
+		return strconv.Itoa(n), nil
+	case string:
+		return n, nil
+	default:
+		return "", fmt.Errorf("%v is not a number", number)
+	}
+}
+
+func exactNumber(number any) (*big.Rat, error) {
+	s, err := decimalString(number)
+
+	if err != nil {
+		return nil, err
+	}
+
+	x, ok := new(big.Rat).SetString(s)
+
+	if !ok {
+		return nil, fmt.Errorf("%s is not a number", s)
+	}
+
+	return x, nil
+}
+
+// writeTemplates renders each template into its file
+func writeTemplates(destination string, templates []Template, rates *frankfurter.ExchangeRates) error {
+	data := newTemplateData(rates)
+
+	for _, t := range templates {
+		parsed, err := t.parse(rates)
+
+		if err != nil {
+			return err
+		}
+
+		var buffer bytes.Buffer
+
+		err = parsed.Execute(&buffer, data)
+
+		if err != nil {
+			return fmt.Errorf("unable to render template for %s: %w", t.File, err)
+		}
+
+		file := path.Join(destination, t.File)
+
+		err = os.MkdirAll(path.Dir(file), 0755)
+
+		if err != nil {
+			return err
+		}
+
+		err = os.WriteFile(file, buffer.Bytes(), 0644)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
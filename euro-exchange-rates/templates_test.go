@@ -0,0 +1,173 @@
+package euroexchangerates_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/concourse-resource-go"
+	xr "github.com/suhlig/euro-exchange-rates-resource/euro-exchange-rates"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Templates", func() {
+	var (
+		err     error
+		request concourse.GetRequest[xr.Source, xr.Version, xr.Params]
+	)
+
+	BeforeEach(func() {
+		request = concourse.GetRequest[xr.Source, xr.Version, xr.Params]{}
+		request.Source.URL = server.URL
+		request.Source.Currencies = []frankfurter.Currency{frankfurter.Currency("USD"), frankfurter.Currency("SEK")}
+
+		date, e := frankfurter.NewYMD("2024-01-15")
+		Expect(e).ToNot(HaveOccurred())
+		request.Version = xr.Version{Date: date}
+
+		responseBody = `{ "amount": 1.0, "base": "EUR", "date": "2024-01-15", "rates": { "USD": 1.0882, "SEK": 11.3215 } }`
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		_, err = resource.Get(ctx, request, GinkgoWriter, inputDir)
+	})
+
+	Context("inline", func() {
+		BeforeEach(func() {
+			request.Params.Templates = []xr.Template{{
+				File:     "reports/rates.txt",
+				Template: `{{ .Date }}{{ range .Currencies }} {{ . }}={{ index $.Rates . }}{{ end }}`,
+			}}
+		})
+
+		It("works", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("renders into the named file", func() {
+			Expect(file("reports/rates.txt")).To(Equal("2024-01-15 SEK=11.3215 USD=1.0882"))
+		})
+	})
+
+	Describe("helpers", func() {
+		BeforeEach(func() {
+			request.Params.Templates = []xr.Template{{File: "out.txt"}}
+		})
+
+		JustBeforeEach(func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("format", func() {
+			BeforeEach(func() {
+				request.Params.Templates[0].Template = `{{ format .Rates.USD }} {{ format .Rates.SEK "de-DE" }}`
+			})
+
+			It("writes rates as published, optionally localized", func() {
+				Expect(file("out.txt")).To(Equal("1.0882 11,3215"))
+			})
+		})
+
+		Context("round", func() {
+			BeforeEach(func() {
+				request.Params.Templates[0].Template = `{{ round .Rates.SEK 2 }}`
+			})
+
+			It("rounds", func() {
+				Expect(file("out.txt")).To(Equal("11.32"))
+			})
+		})
+
+		Context("invert", func() {
+			BeforeEach(func() {
+				request.Params.Templates[0].Template = `{{ round (invert .Rates.USD) 4 }}`
+			})
+
+			It("inverts", func() {
+				Expect(file("out.txt")).To(Equal("0.9189"))
+			})
+		})
+
+		Context("convert", func() {
+			BeforeEach(func() {
+				request.Params.Templates[0].Template = `{{ format (round (convert 100 "USD" "SEK") 2) }} {{ convert 10 "EUR" "USD" }}`
+			})
+
+			It("converts between currencies", func() {
+				Expect(file("out.txt")).To(Equal("1040.39 10.882"))
+			})
+		})
+	})
+
+	Context("built-in", func() {
+		BeforeEach(func() {
+			request.Params.Templates = []xr.Template{
+				{File: "rates.md", Builtin: "markdown"},
+				{File: "rates.sh", Builtin: "shell"},
+			}
+		})
+
+		It("renders Markdown", func() {
+			Expect(file("rates.md")).To(Equal(`# Euro exchange rates as of 2024-01-15
+
+| Currency | 1 EUR |
+|----------|-------:|
+| SEK | 11.3215 |
+| USD | 1.0882 |
+`))
+		})
+
+		It("renders a shell script", func() {
+			Expect(file("rates.sh")).To(Equal("export RATES_DATE=2024-01-15\nexport EUR_SEK=11.3215\nexport EUR_USD=1.0882\n"))
+		})
+	})
+
+	Context("unknown built-in", func() {
+		BeforeEach(func() {
+			request.Params.Templates = []xr.Template{{File: "rates.xml", Builtin: "xml"}}
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring(`unknown built-in template "xml"`)))
+		})
+	})
+
+	Context("both inline and built-in", func() {
+		BeforeEach(func() {
+			request.Params.Templates = []xr.Template{{File: "rates.md", Builtin: "markdown", Template: "{{ .Date }}"}}
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring("template for rates.md is both inline and built-in")))
+		})
+	})
+
+	Context("a syntax error", func() {
+		BeforeEach(func() {
+			request.Params.Templates = []xr.Template{{File: "out.txt", Template: "{{ .Date "}}
+		})
+
+		It("fails before fetching", func() {
+			Expect(err).To(MatchError(ContainSubstring("unable to parse template for out.txt")))
+			Expect(requestURL).To(BeNil())
+		})
+	})
+
+	Context("a missing rate", func() {
+		BeforeEach(func() {
+			request.Params.Templates = []xr.Template{{File: "out.txt", Template: "{{ .Rates.GBP }}"}}
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring("unable to render template for out.txt")))
+		})
+	})
+
+	Context("a file outside of the destination", func() {
+		BeforeEach(func() {
+			request.Params.Templates = []xr.Template{{File: "../out.txt", Template: "{{ .Date }}"}}
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring("must not leave the destination")))
+		})
+	})
+})
@@ -0,0 +1,97 @@
+package frankfurter
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// BatchOptions control how AtEach fetches many dates
+type BatchOptions struct {
+	// Workers is the number of concurrent requests; defaults to 4
+	Workers int
+
+	// RequestsPerSecond limits the rate of requests across all workers; zero means unlimited
+	RequestsPerSecond float64
+}
+
+// BatchResult holds the outcome of fetching the rates of a single date
+type BatchResult struct {
+	Date  YMD
+	Rates *ExchangeRates
+	Err   error
+}
+
+// AtEach fetches the rates at each of the given dates concurrently, with a bounded number of workers and an optional
+// rate limit. Duplicate dates are fetched only once.
+//
+// The results are in chronological order, one for each distinct date. Like with At, Frankfurter returns the closest
+// rates for dates without a publication, so Rates.Date may differ from Date. Once the context is cancelled, the
+// remaining dates are not fetched anymore; their results carry the error of the context.
+func (s ExchangeRatesService) AtEach(ctx context.Context, dates []YMD, options BatchOptions, currencies ...Currency) []BatchResult {
+	results := distinct(dates)
+
+	workers := options.Workers
+
+	if workers <= 0 {
+		workers = 4
+	}
+
+	limiter := newLimiter(options.RequestsPerSecond)
+
+	jobs := make(chan *BatchResult)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for result := range jobs {
+				if _, err := limiter.wait(ctx); err != nil {
+					result.Err = err
+					continue
+				}
+
+				result.Rates, result.Err = s.At(ctx, result.Date, currencies...)
+			}
+		}()
+	}
+
+	for i := range results {
+		if ctx.Err() != nil {
+			results[i].Err = ctx.Err()
+			continue
+		}
+
+		jobs <- &results[i]
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// distinct returns one result per distinct date, in chronological order
+func distinct(dates []YMD) []BatchResult {
+	seen := make(map[string]bool, len(dates))
+	results := make([]BatchResult, 0, len(dates))
+
+	for _, date := range dates {
+		if seen[date.String()] {
+			continue
+		}
+
+		seen[date.String()] = true
+		results = append(results, BatchResult{Date: date})
+	}
+
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Date.Before(results[j].Date)
+	})
+
+	return results
+}
@@ -0,0 +1,141 @@
+package frankfurter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("AtEach", func() {
+	var (
+		server     *httptest.Server
+		service    frankfurter.ExchangeRatesService
+		dates      []frankfurter.YMD
+		options    frankfurter.BatchOptions
+		results    []frankfurter.BatchResult
+		requested  []string
+		mutex      sync.Mutex
+		concurrent atomic.Int32
+		peak       atomic.Int32
+	)
+
+	ymd := func(s string) frankfurter.YMD {
+		date, err := frankfurter.NewYMD(s)
+		Expect(err).ToNot(HaveOccurred())
+		return date
+	}
+
+	BeforeEach(func() {
+		requested = nil
+		concurrent.Store(0)
+		peak.Store(0)
+		options = frankfurter.BatchOptions{Workers: 2}
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			current := concurrent.Add(1)
+			defer concurrent.Add(-1)
+
+			for {
+				previous := peak.Load()
+
+				if current <= previous || peak.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+
+			mutex.Lock()
+			requested = append(requested, r.URL.Path)
+			mutex.Unlock()
+
+			date := strings.TrimPrefix(r.URL.Path, "/")
+
+			if date == "2024-01-13" {
+				date = "2024-01-12" // Saturday
+			}
+
+			if date == "2024-01-17" {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			fmt.Fprintf(w, `{ "amount": 1.0, "base": "EUR", "date": "%s", "rates": { "USD": 1.0882 } }`, date)
+		}))
+
+		service = frankfurter.ExchangeRatesService{URL: server.URL, HttpClient: server.Client()}
+
+		dates = []frankfurter.YMD{
+			ymd("2024-01-16"),
+			ymd("2024-01-13"),
+			ymd("2024-01-15"),
+			ymd("2024-01-16"),
+			ymd("2024-01-12"),
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		results = service.AtEach(ctx, dates, options, frankfurter.Currency("USD"))
+	})
+
+	It("fetches each distinct date once", func() {
+		Expect(requested).To(ConsistOf("/2024-01-12", "/2024-01-13", "/2024-01-15", "/2024-01-16"))
+	})
+
+	It("returns the results in chronological order", func() {
+		Expect(results).To(HaveLen(4))
+		Expect(results[0].Date.String()).To(Equal("2024-01-12"))
+		Expect(results[3].Date.String()).To(Equal("2024-01-16"))
+	})
+
+	It("reports the effective date", func() {
+		Expect(results[1].Date.String()).To(Equal("2024-01-13"))
+		Expect(results[1].Rates.Date.String()).To(Equal("2024-01-12"))
+	})
+
+	It("does not exceed the number of workers", func() {
+		Expect(peak.Load()).To(BeNumerically("<=", 2))
+	})
+
+	Context("one date fails", func() {
+		BeforeEach(func() {
+			dates = append(dates, ymd("2024-01-17"))
+		})
+
+		It("reports the error for that date only", func() {
+			Expect(results).To(HaveLen(5))
+			Expect(results[4].Err).To(HaveOccurred())
+			Expect(results[3].Err).ToNot(HaveOccurred())
+		})
+	})
+
+	Context("context cancelled", func() {
+		JustBeforeEach(func(ctx SpecContext) {
+			cancelled, cancel := context.WithCancel(ctx)
+			cancel()
+
+			requested = nil
+			results = service.AtEach(cancelled, dates, options)
+		})
+
+		It("does not fetch anything", func() {
+			Expect(requested).To(BeEmpty())
+		})
+
+		It("reports the cancellation for each date", func() {
+			for _, result := range results {
+				Expect(result.Err).To(MatchError(context.Canceled))
+			}
+		})
+	})
+})
@@ -1,11 +1,19 @@
 package frankfurter
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
+
+	"github.com/suhlig/euro-exchange-rates-resource/calendar"
 )
 
 // Latest fetches the latest rates
@@ -25,91 +33,307 @@ func (s ExchangeRatesService) Latest(ctx context.Context, currencies ...Currency
 //
 // [API Documentation]: https://www.frankfurter.app/docs/#historical
 func (s ExchangeRatesService) At(ctx context.Context, date YMD, currencies ...Currency) (*ExchangeRates, error) {
-	var (
-		urlWithPath string
-		err         error
-	)
+	path := "latest"
 
-	if date.IsZero() {
-		urlWithPath, err = url.JoinPath(s.URL, "latest")
-	} else {
-		urlWithPath, err = url.JoinPath(s.URL, date.String())
+	if !date.IsZero() {
+		path = date.String()
 	}
 
+	body, err := s.fetch(ctx, path, currencies...)
+
 	if err != nil {
 		return nil, err
 	}
 
-	if len(currencies) > 0 {
-		query := url.Values{}
-		query.Add("to", strings.Join(mapFunc(currencies, func(c Currency) string { return string(c) }), ","))
-		urlWithPath = urlWithPath + "?" + query.Encode()
-	}
+	defer body.Close()
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, urlWithPath, nil)
+	var rates ExchangeRates
+
+	err = json.NewDecoder(body).Decode(&rates)
 
 	if err != nil {
 		return nil, err
 	}
 
-	request.Header.Set("User-Agent", "Concourse Euro Exchange Rates Resource; https://github.com/suhlig/euro-exchange-rates-resource")
+	return &rates, nil
+}
 
-	httpResponse, err := s.HttpClient.Do(request)
+// Since fetches the rates between the given date and now
+//
+// Frankfurter downsamples long time series (e.g. to weekly data points). If that happens, the range is split into
+// smaller chunks until the data is daily, so that no publication is skipped. The whole time series is kept in memory;
+// EachSince does the same, but passes one date at a time to a function instead.
+//
+// [API Documentation]: https://www.frankfurter.app/docs/#timeseries
+func (s ExchangeRatesService) Since(ctx context.Context, date YMD, currencies ...Currency) (*History, error) {
+	return s.history(ctx, date, YMD{}, currencies...)
+}
+
+// Between fetches the rates between the given start and end dates (both inclusive)
+//
+// Like Since, it makes sure that the result has daily granularity.
+//
+// [API Documentation]: https://www.frankfurter.app/docs/#timeseries
+func (s ExchangeRatesService) Between(ctx context.Context, start, end YMD, currencies ...Currency) (*History, error) {
+	return s.history(ctx, start, end, currencies...)
+}
+
+// history fetches the time series from start to end; an open end means until now.
+func (s ExchangeRatesService) history(ctx context.Context, start, end YMD, currencies ...Currency) (*History, error) {
+	history, err := s.timeSeries(ctx, start.String()+".."+endString(end), currencies...)
+
+	if err != nil || !history.Sampled() {
+		return history, err
+	}
+
+	// An open end is only resolved to find the middle; the last chunk stays open so that it includes publications
+	// after the last sampled date.
+	last := end
+
+	if last.IsZero() {
+		dates := history.Dates()
+		last = dates[len(dates)-1]
+	}
+
+	if days(start, last) <= 7 {
+		return history, nil // cannot be split any further; must be actual gaps
+	}
+
+	middle := start.AddDays(days(start, last) / 2)
+
+	first, err := s.history(ctx, start, middle, currencies...)
 
 	if err != nil {
 		return nil, err
 	}
 
-	var rates ExchangeRates
-
-	err = json.NewDecoder(httpResponse.Body).Decode(&rates)
+	second, err := s.history(ctx, middle.AddDays(1), end, currencies...)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &rates, nil
+	return first.merge(second), nil
 }
 
-// Since fetches the rates between the given date and now
+// EachSince fetches the rates between the given date and now, and calls f for each date in chronological order
+// without keeping the whole time series in memory. If f returns an error, fetching stops and the error is returned.
+//
+// Like Since, it splits downsampled ranges until the data is daily, so that f sees every publication.
 //
 // [API Documentation]: https://www.frankfurter.app/docs/#timeseries
-func (s ExchangeRatesService) Since(ctx context.Context, date YMD, currencies ...Currency) (*History, error) {
-	urlWithPath, err := url.JoinPath(s.URL, date.String()+"..")
+func (s ExchangeRatesService) EachSince(ctx context.Context, date YMD, f func(YMD, Rates) error, currencies ...Currency) (*History, error) {
+	var latest YMD
+
+	return s.each(ctx, date, YMD{}, true, func(date YMD, rates Rates) error {
+		if !latest.IsZero() && !latest.Before(date) {
+			return nil // passed before the chunk was found to be downsampled
+		}
+
+		latest = date
+
+		return f(date, rates)
+	}, currencies...)
+}
+
+// each streams the time series from start to end (an open end means until now) to f, splitting it like history
+// does. Once a gap reveals that the response was downsampled, the remaining dates are only decoded to find the last
+// one, and the range is fetched again in halves. As the halves repeat the dates passed before the gap, f needs to
+// skip dates it has seen already. Unless split is set, every date is passed to f.
+func (s ExchangeRatesService) each(ctx context.Context, start, end YMD, split bool, f func(YMD, Rates) error, currencies ...Currency) (*History, error) {
+	body, err := s.fetch(ctx, start.String()+".."+endString(end), currencies...)
 
 	if err != nil {
 		return nil, err
 	}
 
+	defer body.Close()
+
+	var previous, last YMD
+	sampled := false
+
+	history, err := DecodeHistory(body, func(date YMD, rates Rates) error {
+		if split && !previous.IsZero() && days(previous, date) > calendar.MaxPublicationGap {
+			sampled = true
+		}
+
+		previous, last = date, date
+
+		if sampled {
+			return nil
+		}
+
+		return f(date, rates)
+	})
+
+	if err != nil || !sampled {
+		return history, err
+	}
+
+	// same as in history; an open end stays open for the last chunk
+	if !end.IsZero() {
+		last = end
+	}
+
+	if days(start, last) <= 7 {
+		return s.each(ctx, start, end, false, f, currencies...) // cannot be split any further; must be actual gaps
+	}
+
+	middle := start.AddDays(days(start, last) / 2)
+
+	first, err := s.each(ctx, start, middle, true, f, currencies...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	second, err := s.each(ctx, middle.AddDays(1), end, true, f, currencies...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return first.merge(second), nil
+}
+
+func (s ExchangeRatesService) timeSeries(ctx context.Context, dateRange string, currencies ...Currency) (*History, error) {
+	body, err := s.fetch(ctx, dateRange, currencies...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer body.Close()
+
+	rates := make(RatesAt)
+
+	history, err := DecodeHistory(body, func(date YMD, r Rates) error {
+		rates[date] = r
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	history.Rates = rates
+
+	return history, nil
+}
+
+// fetch gets the given path from the service (or one of its mirrors) and returns the response body
+func (s ExchangeRatesService) fetch(ctx context.Context, path string, currencies ...Currency) (io.ReadCloser, error) {
+	var query string
+	values := url.Values{}
+
 	if len(currencies) > 0 {
-		query := url.Values{}
-		query.Add("to", strings.Join(mapFunc(currencies, func(c Currency) string { return string(c) }), ","))
-		urlWithPath = urlWithPath + "?" + query.Encode()
+		values.Add("to", strings.Join(mapFunc(currencies, func(c Currency) string { return string(c) }), ","))
 	}
 
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, urlWithPath, nil)
+	if s.Base != "" {
+		values.Add("from", string(s.Base))
+	}
+
+	if s.Amount != 0 {
+		values.Add("amount", strconv.FormatFloat(float64(s.Amount), 'f', -1, 32))
+	}
+
+	if len(values) > 0 {
+		query = "?" + values.Encode()
+	}
+
+	if s.Mirrors == nil {
+		return s.fetchFrom(ctx, s.URL, path, query)
+	}
+
+	var errs []error
+
+	for _, base := range s.Mirrors.order(ctx, s.HttpClient) {
+		body, err := s.fetchFrom(ctx, base, path, query)
+
+		if err == nil {
+			s.Mirrors.served(base, path)
+			return body, nil
+		}
+
+		if ctx.Err() != nil || !retryable(err) {
+			return nil, err
+		}
+
+		s.Mirrors.failed(base, err)
+		errs = append(errs, err)
+	}
+
+	return nil, fmt.Errorf("no mirror could serve %s: %w", path, errors.Join(errs...))
+}
+
+// fetchFrom gets the path from the given base URL, going through the governor if there is one
+func (s ExchangeRatesService) fetchFrom(ctx context.Context, base, path, query string) (io.ReadCloser, error) {
+	urlWithPath, err := url.JoinPath(base, path)
 
 	if err != nil {
 		return nil, err
 	}
 
-	request.Header.Set("User-Agent", "Concourse Euro Exchange Rates Resource; https://github.com/suhlig/euro-exchange-rates-resource")
+	urlWithPath = urlWithPath + query
 
-	httpResponse, err := s.HttpClient.Do(request)
+	if s.Governor == nil {
+		return s.get(ctx, urlWithPath)
+	}
+
+	body, err := s.Governor.do(ctx, urlWithPath, func(ctx context.Context) ([]byte, error) {
+		body, err := s.get(ctx, urlWithPath)
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer body.Close()
+
+		return io.ReadAll(body)
+	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	var history History
+	return io.NopCloser(bytes.NewReader(body)), nil
+}
 
-	err = json.NewDecoder(httpResponse.Body).Decode(&history)
+func (s ExchangeRatesService) get(ctx context.Context, urlWithPath string) (io.ReadCloser, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, urlWithPath, nil)
 
 	if err != nil {
 		return nil, err
 	}
 
-	return &history, nil
+	request.Header.Set("User-Agent", "Concourse Euro Exchange Rates Resource; https://github.com/suhlig/euro-exchange-rates-resource")
+
+	httpResponse, err := s.HttpClient.Do(request)
+
+	if err != nil {
+		return nil, connectionError{err}
+	}
+
+	if httpResponse.StatusCode >= http.StatusInternalServerError {
+		httpResponse.Body.Close()
+		return nil, serverError{URL: urlWithPath, StatusCode: httpResponse.StatusCode}
+	}
+
+	return httpResponse.Body, nil
+}
+
+func endString(end YMD) string {
+	if end.IsZero() {
+		return ""
+	}
+
+	return end.String()
+}
+
+// days returns the number of calendar days from start to end
+func days(start, end YMD) int {
+	return int(time.Time(end).Sub(time.Time(start)).Round(24*time.Hour) / (24 * time.Hour))
 }
 
 // https://stackoverflow.com/a/71624929
@@ -0,0 +1,194 @@
+package frankfurter_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Client", func() {
+	var (
+		server  *httptest.Server
+		service frankfurter.ExchangeRatesService
+		query   url.Values
+		rates   *frankfurter.ExchangeRates
+		err     error
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.Query()
+			fmt.Fprintln(w, `{ "amount": 100.0, "base": "USD", "date": "2024-01-15", "rates": { "SEK": 1040.38 } }`)
+		}))
+
+		service = frankfurter.ExchangeRatesService{URL: server.URL, HttpClient: server.Client()}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		date, e := frankfurter.NewYMD("2024-01-15")
+		Expect(e).ToNot(HaveOccurred())
+
+		rates, err = service.At(ctx, date, frankfurter.Currency("SEK"))
+	})
+
+	Context("defaults", func() {
+		It("only asks for the currencies", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(query).To(Equal(url.Values{"to": {"SEK"}}))
+		})
+	})
+
+	Context("base and amount", func() {
+		BeforeEach(func() {
+			service.Base = frankfurter.Currency("USD")
+			service.Amount = 100
+		})
+
+		It("passes them on", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(query).To(Equal(url.Values{"to": {"SEK"}, "from": {"USD"}, "amount": {"100"}}))
+		})
+
+		It("returns the converted rates", func() {
+			Expect(rates.Base).To(Equal(frankfurter.Currency("USD")))
+			Expect(rates.Amount).To(BeNumerically("==", 100))
+			Expect(rates.Rates).To(HaveKeyWithValue(frankfurter.Currency("SEK"), BeNumerically("~", 1040.38, 0.001)))
+		})
+	})
+})
+
+var _ = Describe("Downsampled time series", func() {
+	var (
+		server    *httptest.Server
+		responses map[string]string
+		date      frankfurter.YMD
+		err       error
+	)
+
+	BeforeEach(func() {
+		responses = map[string]string{
+			// sampled weekly, and the last sample is older than the latest publication
+			"/2024-01-02..": `
+				{
+					"amount": 1.0,
+					"base": "EUR",
+					"start_date": "2024-01-02",
+					"end_date": "2024-01-16",
+					"rates": {
+						"2024-01-02": { "USD": 1.0956 },
+						"2024-01-09": { "USD": 1.0940 },
+						"2024-01-16": { "USD": 1.0882 }
+					}
+				}
+			`,
+			"/2024-01-02..2024-01-09": `
+				{
+					"amount": 1.0,
+					"base": "EUR",
+					"start_date": "2024-01-02",
+					"end_date": "2024-01-09",
+					"rates": {
+						"2024-01-02": { "USD": 1.0956 },
+						"2024-01-03": { "USD": 1.0919 },
+						"2024-01-04": { "USD": 1.0953 },
+						"2024-01-05": { "USD": 1.0921 },
+						"2024-01-08": { "USD": 1.0946 },
+						"2024-01-09": { "USD": 1.094 }
+					}
+				}
+			`,
+			"/2024-01-10..": `
+				{
+					"amount": 1.0,
+					"base": "EUR",
+					"start_date": "2024-01-10",
+					"end_date": "2024-01-18",
+					"rates": {
+						"2024-01-10": { "USD": 1.0946 },
+						"2024-01-11": { "USD": 1.0977 },
+						"2024-01-12": { "USD": 1.0969 },
+						"2024-01-15": { "USD": 1.0887 },
+						"2024-01-16": { "USD": 1.0882 },
+						"2024-01-17": { "USD": 1.0877 },
+						"2024-01-18": { "USD": 1.0875 }
+					}
+				}
+			`,
+		}
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			response, found := responses[r.URL.Path]
+
+			if !found {
+				http.NotFound(w, r)
+				return
+			}
+
+			fmt.Fprintln(w, response)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		var e error
+		date, e = frankfurter.NewYMD("2024-01-02")
+		Expect(e).ToNot(HaveOccurred())
+	})
+
+	Context("Since", func() {
+		var history *frankfurter.History
+
+		JustBeforeEach(func(ctx SpecContext) {
+			service := frankfurter.ExchangeRatesService{URL: server.URL, HttpClient: server.Client()}
+			history, err = service.Since(ctx, date, frankfurter.Currency("USD"))
+		})
+
+		It("works", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("fetches the publications after the last sampled date", func() {
+			dates := history.Dates()
+
+			Expect(dates).To(HaveLen(13))
+			Expect(dates[len(dates)-1].String()).To(Equal("2024-01-18"))
+		})
+	})
+
+	Context("EachSince", func() {
+		var dates []string
+
+		JustBeforeEach(func(ctx SpecContext) {
+			dates = nil
+			service := frankfurter.ExchangeRatesService{URL: server.URL, HttpClient: server.Client()}
+
+			_, err = service.EachSince(ctx, date, func(date frankfurter.YMD, _ frankfurter.Rates) error {
+				dates = append(dates, date.String())
+				return nil
+			}, frankfurter.Currency("USD"))
+		})
+
+		It("works", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("passes every publication once, in chronological order", func() {
+			Expect(dates).To(HaveExactElements(
+				"2024-01-02", "2024-01-03", "2024-01-04", "2024-01-05", "2024-01-08", "2024-01-09", "2024-01-10",
+				"2024-01-11", "2024-01-12", "2024-01-15", "2024-01-16", "2024-01-17", "2024-01-18",
+			))
+		})
+	})
+})
@@ -0,0 +1,113 @@
+package frankfurter
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// DecodeHistory reads a time series as returned by Frankfurter from r, walking the rates token by token instead of
+// decoding them into memory at once. The function f is called for each date in the order in which the dates appear,
+// which is chronological for Frankfurter. If f returns an error, decoding stops and the error is returned.
+//
+// The returned History has all fields populated except for the rates, which are only passed to f.
+func DecodeHistory(r io.Reader, f func(YMD, Rates) error) (*History, error) {
+	decoder := json.NewDecoder(r)
+
+	err := expectDelim(decoder, '{')
+
+	if err != nil {
+		return nil, err
+	}
+
+	var history History
+
+	for decoder.More() {
+		key, err := decoder.Token()
+
+		if err != nil {
+			return nil, err
+		}
+
+		switch key {
+		case "amount":
+			err = decoder.Decode(&history.Amount)
+		case "base":
+			err = decoder.Decode(&history.Base)
+		case "start_date":
+			err = decoder.Decode(&history.Start)
+		case "end_date":
+			err = decoder.Decode(&history.End)
+		case "rates":
+			err = decodeRatesAt(decoder, f)
+		default:
+			var ignored json.RawMessage
+			err = decoder.Decode(&ignored)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("could not decode %v: %w", key, err)
+		}
+	}
+
+	err = expectDelim(decoder, '}')
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &history, nil
+}
+
+// decodeRatesAt walks an object of rates keyed by date
+func decodeRatesAt(decoder *json.Decoder, f func(YMD, Rates) error) error {
+	err := expectDelim(decoder, '{')
+
+	if err != nil {
+		return err
+	}
+
+	for decoder.More() {
+		key, err := decoder.Token()
+
+		if err != nil {
+			return err
+		}
+
+		date, err := NewYMD(fmt.Sprint(key))
+
+		if err != nil {
+			return err
+		}
+
+		var rates Rates
+
+		err = decoder.Decode(&rates)
+
+		if err != nil {
+			return fmt.Errorf("could not decode rates as of %s: %w", date, err)
+		}
+
+		err = f(date, rates)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(decoder, '}')
+}
+
+func expectDelim(decoder *json.Decoder, expected json.Delim) error {
+	token, err := decoder.Token()
+
+	if err != nil {
+		return err
+	}
+
+	if token != expected {
+		return fmt.Errorf("expected %v, but got %v", expected, token)
+	}
+
+	return nil
+}
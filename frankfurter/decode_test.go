@@ -0,0 +1,192 @@
+package frankfurter_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("DecodeHistory", func() {
+	var (
+		input   string
+		history *frankfurter.History
+		dates   []string
+		err     error
+	)
+
+	BeforeEach(func() {
+		dates = nil
+		input = `
+			{
+				"amount": 1.0,
+				"base": "EUR",
+				"start_date": "2024-01-15",
+				"end_date": "2024-01-17",
+				"rates": {
+					"2024-01-15": { "SEK": 11.3215, "USD": 1.0887 },
+					"2024-01-16": { "SEK": 11.33, "USD": 1.089 },
+					"2024-01-17": { "SEK": 11.3412, "USD": 1.0872 }
+				},
+				"unknown": [1, 2, 3]
+			}
+		`
+	})
+
+	JustBeforeEach(func() {
+		history, err = frankfurter.DecodeHistory(strings.NewReader(input), func(date frankfurter.YMD, rates frankfurter.Rates) error {
+			dates = append(dates, fmt.Sprintf("%s %v", date, rates["USD"]))
+			return nil
+		})
+	})
+
+	It("works", func() {
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("yields the dates in order", func() {
+		Expect(dates).To(Equal([]string{"2024-01-15 1.0887", "2024-01-16 1.089", "2024-01-17 1.0872"}))
+	})
+
+	It("populates the other fields", func() {
+		Expect(history.Base).To(Equal(frankfurter.Currency("EUR")))
+		Expect(history.Start.String()).To(Equal("2024-01-15"))
+		Expect(history.End.String()).To(Equal("2024-01-17"))
+	})
+
+	Context("callback fails", func() {
+		var stop = errors.New("enough")
+
+		JustBeforeEach(func() {
+			dates = nil
+			_, err = frankfurter.DecodeHistory(strings.NewReader(input), func(date frankfurter.YMD, rates frankfurter.Rates) error {
+				dates = append(dates, date.String())
+				return stop
+			})
+		})
+
+		It("stops", func() {
+			Expect(err).To(MatchError(stop))
+			Expect(dates).To(HaveLen(1))
+		})
+	})
+
+	Context("malformed date", func() {
+		BeforeEach(func() {
+			input = `{ "rates": { "yesterday": { "USD": 1.0 } } }`
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring("YYYY-MM-DD")))
+		})
+	})
+})
+
+// longHistory is a synthetic time series of 25 years with 30 currencies, which is about the size of the full history
+func longHistory() []byte {
+	var buffer bytes.Buffer
+
+	buffer.WriteString(`{"amount":1.0,"base":"EUR","start_date":"1999-01-04","end_date":"2023-12-29","rates":{`)
+
+	day := time.Date(1999, time.January, 4, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 25*261; i++ {
+		if i > 0 {
+			buffer.WriteString(",")
+		}
+
+		fmt.Fprintf(&buffer, `"%s":{`, day.Format(time.DateOnly))
+
+		for c := 0; c < 30; c++ {
+			if c > 0 {
+				buffer.WriteString(",")
+			}
+
+			fmt.Fprintf(&buffer, `"C%02d":%d.%04d`, c, c+1, i%10000)
+		}
+
+		buffer.WriteString("}")
+		day = day.AddDate(0, 0, 1)
+	}
+
+	buffer.WriteString("}}")
+
+	return buffer.Bytes()
+}
+
+// BenchmarkDecodeNaively decodes the way this package used to: into a map keyed by strings first, which is then copied
+// while loading the time zone for every date.
+func BenchmarkDecodeNaively(b *testing.B) {
+	input := longHistory()
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var raw struct {
+			Rates map[string]frankfurter.Rates `json:"rates"`
+		}
+
+		err := json.Unmarshal(input, &raw)
+
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		rates := make(frankfurter.RatesAt, len(raw.Rates))
+
+		for k, v := range raw.Rates {
+			location, err := time.LoadLocation("Europe/Berlin")
+
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			t, err := time.ParseInLocation(time.DateOnly, k, location)
+
+			if err != nil {
+				b.Fatal(err)
+			}
+
+			rates[frankfurter.YMD(t.Add(16*time.Hour))] = v
+		}
+	}
+}
+
+func BenchmarkUnmarshalHistory(b *testing.B) {
+	input := longHistory()
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		var history frankfurter.History
+
+		err := json.Unmarshal(input, &history)
+
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDecodeHistory(b *testing.B) {
+	input := longHistory()
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, err := frankfurter.DecodeHistory(bytes.NewReader(input), func(frankfurter.YMD, frankfurter.Rates) error {
+			return nil
+		})
+
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
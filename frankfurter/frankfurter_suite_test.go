@@ -0,0 +1,13 @@
+package frankfurter_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestFrankfurter(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Frankfurter Suite")
+}
@@ -0,0 +1,154 @@
+package frankfurter
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Governor coalesces identical concurrent requests and limits the rate of requests to the API, so that services
+// embedding this package are good citizens towards the public API. It is safe for concurrent use and meant to be
+// shared by all copies of an ExchangeRatesService.
+type Governor struct {
+	limiter *limiter
+
+	mutex    sync.Mutex
+	inflight map[string]*call
+
+	requests  atomic.Uint64
+	coalesced atomic.Uint64
+	limited   atomic.Uint64
+}
+
+// GovernorStats counts what a Governor did
+type GovernorStats struct {
+	Requests  uint64 // requests actually sent to the API
+	Coalesced uint64 // calls that were served by an identical request already in flight
+	Limited   uint64 // requests that were delayed by the rate limit
+}
+
+type call struct {
+	done chan struct{}
+	body []byte
+	err  error
+}
+
+// NewGovernor creates a Governor that sends at most requestsPerSecond requests per second; zero means unlimited.
+func NewGovernor(requestsPerSecond float64) *Governor {
+	return &Governor{
+		limiter:  newLimiter(requestsPerSecond),
+		inflight: make(map[string]*call),
+	}
+}
+
+// Stats returns the counters of the governor
+func (g *Governor) Stats() GovernorStats {
+	return GovernorStats{
+		Requests:  g.requests.Load(),
+		Coalesced: g.coalesced.Load(),
+		Limited:   g.limited.Load(),
+	}
+}
+
+// do calls fetch unless a call with the same key is already in flight, in which case it waits for that call's result.
+//
+// The shared call does not belong to any single caller, so it runs detached from the cancellation of the context that
+// started it; otherwise one caller giving up would fail everyone waiting for the same key. Each caller only stops
+// waiting when its own context is done.
+func (g *Governor) do(ctx context.Context, key string, fetch func(context.Context) ([]byte, error)) ([]byte, error) {
+	g.mutex.Lock()
+	c, found := g.inflight[key]
+
+	if found {
+		g.mutex.Unlock()
+		g.coalesced.Add(1)
+	} else {
+		c = &call{done: make(chan struct{})}
+		g.inflight[key] = c
+		g.mutex.Unlock()
+
+		go g.call(context.WithoutCancel(ctx), key, c, fetch)
+	}
+
+	select {
+	case <-c.done:
+		return c.body, c.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// call performs the shared call, subject to the rate limit
+func (g *Governor) call(ctx context.Context, key string, c *call, fetch func(context.Context) ([]byte, error)) {
+	defer func() {
+		g.mutex.Lock()
+		delete(g.inflight, key)
+		g.mutex.Unlock()
+		close(c.done)
+	}()
+
+	limited, err := g.limiter.wait(ctx)
+
+	if limited {
+		g.limited.Add(1)
+	}
+
+	if err != nil {
+		c.err = err
+		return
+	}
+
+	g.requests.Add(1)
+	c.body, c.err = fetch(ctx)
+}
+
+// limiter hands out evenly spaced slots for requests. A nil limiter does not limit at all.
+type limiter struct {
+	interval time.Duration
+
+	mutex sync.Mutex
+	next  time.Time
+}
+
+func newLimiter(requestsPerSecond float64) *limiter {
+	if requestsPerSecond <= 0 {
+		return nil
+	}
+
+	return &limiter{interval: time.Duration(float64(time.Second) / requestsPerSecond)}
+}
+
+// wait blocks until the next slot is available and tells whether it had to wait at all.
+func (l *limiter) wait(ctx context.Context) (bool, error) {
+	if l == nil {
+		return false, nil
+	}
+
+	l.mutex.Lock()
+	now := time.Now()
+	slot := l.next
+
+	if slot.Before(now) {
+		slot = now
+	}
+
+	l.next = slot.Add(l.interval)
+	l.mutex.Unlock()
+
+	delay := slot.Sub(now)
+
+	if delay <= 0 {
+		return false, nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return true, nil
+	case <-ctx.Done():
+		return true, ctx.Err()
+	}
+}
@@ -0,0 +1,173 @@
+package frankfurter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Governor", func() {
+	var (
+		server   *httptest.Server
+		service  frankfurter.ExchangeRatesService
+		governor *frankfurter.Governor
+		requests atomic.Int32
+		release  chan struct{}
+	)
+
+	BeforeEach(func() {
+		requests.Store(0)
+		release = make(chan struct{})
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests.Add(1)
+			<-release
+
+			date := strings.TrimPrefix(r.URL.Path, "/")
+
+			if date == "latest" {
+				date = "2024-01-16"
+			}
+
+			fmt.Fprintf(w, `{ "amount": 1.0, "base": "EUR", "date": "%s", "rates": { "USD": 1.0882 } }`, date)
+		}))
+	})
+
+	JustBeforeEach(func() {
+		service = frankfurter.ExchangeRatesService{URL: server.URL, HttpClient: server.Client(), Governor: governor}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("many identical concurrent requests", func() {
+		var (
+			rates []*frankfurter.ExchangeRates
+			errs  []error
+		)
+
+		BeforeEach(func() {
+			governor = frankfurter.NewGovernor(0)
+		})
+
+		JustBeforeEach(func(ctx SpecContext) {
+			rates = make([]*frankfurter.ExchangeRates, 10)
+			errs = make([]error, 10)
+
+			var wg sync.WaitGroup
+
+			for i := range rates {
+				wg.Add(1)
+
+				go func(i int) {
+					defer wg.Done()
+					rates[i], errs[i] = service.Latest(ctx, frankfurter.Currency("USD"))
+				}(i)
+			}
+
+			Eventually(func() uint64 { return governor.Stats().Coalesced }).Should(BeEquivalentTo(9))
+			close(release)
+			wg.Wait()
+		})
+
+		It("sends a single request", func() {
+			Expect(requests.Load()).To(BeEquivalentTo(1))
+			Expect(governor.Stats().Requests).To(BeEquivalentTo(1))
+		})
+
+		It("serves every caller", func() {
+			for i := range rates {
+				Expect(errs[i]).ToNot(HaveOccurred())
+				Expect(rates[i].Date.String()).To(Equal("2024-01-16"))
+			}
+		})
+	})
+
+	Context("the caller that started the request gives up", func() {
+		var (
+			leaderErr   error
+			followerErr error
+			rates       *frankfurter.ExchangeRates
+		)
+
+		BeforeEach(func() {
+			governor = frankfurter.NewGovernor(0)
+		})
+
+		JustBeforeEach(func(ctx SpecContext) {
+			leaderCtx, cancel := context.WithCancel(ctx)
+			leaderDone := make(chan struct{})
+
+			go func() {
+				defer close(leaderDone)
+				_, leaderErr = service.Latest(leaderCtx, frankfurter.Currency("USD"))
+			}()
+
+			Eventually(requests.Load).Should(BeEquivalentTo(1))
+
+			followerDone := make(chan struct{})
+
+			go func() {
+				defer close(followerDone)
+				rates, followerErr = service.Latest(ctx, frankfurter.Currency("USD"))
+			}()
+
+			Eventually(func() uint64 { return governor.Stats().Coalesced }).Should(BeEquivalentTo(1))
+			cancel()
+			<-leaderDone
+			close(release)
+			<-followerDone
+		})
+
+		It("fails for the caller that gave up", func() {
+			Expect(leaderErr).To(MatchError(context.Canceled))
+		})
+
+		It("still serves the other caller", func() {
+			Expect(followerErr).ToNot(HaveOccurred())
+			Expect(rates.Date.String()).To(Equal("2024-01-16"))
+		})
+	})
+
+	Context("rate limit", func() {
+		var elapsed time.Duration
+
+		BeforeEach(func() {
+			governor = frankfurter.NewGovernor(20)
+			close(release)
+		})
+
+		JustBeforeEach(func(ctx SpecContext) {
+			start := time.Now()
+
+			for _, date := range []string{"2024-01-15", "2024-01-16", "2024-01-17"} {
+				ymd, err := frankfurter.NewYMD(date)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = service.At(ctx, ymd)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			elapsed = time.Since(start)
+		})
+
+		It("spaces the requests", func() {
+			Expect(elapsed).To(BeNumerically(">=", 90*time.Millisecond))
+		})
+
+		It("counts the limited requests", func() {
+			Expect(governor.Stats().Limited).To(BeEquivalentTo(2))
+			Expect(governor.Stats().Requests).To(BeEquivalentTo(3))
+		})
+	})
+})
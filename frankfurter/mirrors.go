@@ -0,0 +1,182 @@
+package frankfurter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Strategy decides in which order the mirrors are tried
+type Strategy string
+
+const (
+	// Failover tries the mirrors in the configured order
+	Failover Strategy = "failover"
+
+	// Fastest tries the mirrors in the order of their latency, as measured by a quick probe of each mirror. The probe
+	// runs once per Mirrors value, so a process that creates a new one for each invocation probes every time.
+	Fastest Strategy = "fastest"
+
+	// RoundRobin starts each request with the mirror after the one the previous request started with. The rotation
+	// only lives as long as the Mirrors value; Offset spreads the first requests of separate processes.
+	RoundRobin Strategy = "round-robin"
+)
+
+// probeTimeout limits how long the Fastest strategy waits for a mirror to answer its probe
+const probeTimeout = 5 * time.Second
+
+// Mirrors is a set of base URLs serving the same API. If a mirror fails with a connection error or a 5xx status,
+// the next one is tried. A Mirrors value is safe for concurrent use and meant to be shared.
+type Mirrors struct {
+	URLs     []string
+	Strategy Strategy
+
+	// Log tells which mirror served a request, and which ones failed; optional
+	Log io.Writer
+
+	// Offset shifts the mirror that RoundRobin starts with (modulo the number of mirrors); optional
+	Offset uint32
+
+	next    atomic.Uint32
+	probe   sync.Once
+	fastest []string
+}
+
+// order returns the URLs in the order they should be tried for the next request
+func (m *Mirrors) order(ctx context.Context, client *http.Client) []string {
+	if len(m.URLs) == 0 {
+		return nil
+	}
+
+	switch m.Strategy {
+	case Fastest:
+		m.probe.Do(func() { m.fastest = m.byLatency(ctx, client) })
+		return m.fastest
+	case RoundRobin:
+		start := int((m.Offset + m.next.Add(1) - 1) % uint32(len(m.URLs)))
+		return append(append([]string{}, m.URLs[start:]...), m.URLs[:start]...)
+	default:
+		return m.URLs
+	}
+}
+
+// byLatency probes all mirrors concurrently and sorts them by their response time. Mirrors that fail the probe go last.
+func (m *Mirrors) byLatency(ctx context.Context, client *http.Client) []string {
+	latencies := make([]time.Duration, len(m.URLs))
+
+	var wg sync.WaitGroup
+
+	for i, base := range m.URLs {
+		wg.Add(1)
+
+		go func(i int, base string) {
+			defer wg.Done()
+			latencies[i] = probe(ctx, client, base)
+		}(i, base)
+	}
+
+	wg.Wait()
+
+	indexes := make([]int, len(m.URLs))
+
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return latencies[indexes[i]] < latencies[indexes[j]]
+	})
+
+	ordered := make([]string, len(m.URLs))
+
+	for i, index := range indexes {
+		ordered[i] = m.URLs[index]
+		m.logf("Mirror %s answered the probe in %s\n", m.URLs[index], latencies[index])
+	}
+
+	return ordered
+}
+
+// probe measures how long the mirror takes to serve a minimal request; failures count as the longest duration possible.
+func probe(ctx context.Context, client *http.Client, base string) time.Duration {
+	const unavailable = time.Duration(1<<63 - 1)
+
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	probeURL, err := url.JoinPath(base, "latest")
+
+	if err != nil {
+		return unavailable
+	}
+
+	start := time.Now()
+	body, err := ExchangeRatesService{HttpClient: client}.get(ctx, probeURL+"?to=USD")
+
+	if err != nil {
+		return unavailable
+	}
+
+	_, err = io.Copy(io.Discard, body)
+	body.Close()
+
+	if err != nil {
+		return unavailable
+	}
+
+	return time.Since(start)
+}
+
+func (m *Mirrors) served(base, path string) {
+	m.logf("Served %s from mirror %s\n", path, base)
+}
+
+func (m *Mirrors) failed(base string, err error) {
+	m.logf("Mirror %s failed: %s\n", base, err)
+}
+
+func (m *Mirrors) logf(format string, args ...any) {
+	if m.Log != nil {
+		fmt.Fprintf(m.Log, format, args...)
+	}
+}
+
+// connectionError means that the server could not be reached at all
+type connectionError struct {
+	err error
+}
+
+func (e connectionError) Error() string {
+	return e.err.Error()
+}
+
+func (e connectionError) Unwrap() error {
+	return e.err
+}
+
+// serverError means that the server responded with a 5xx status
+type serverError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e serverError) Error() string {
+	return fmt.Sprintf("%s responded with status %d", e.URL, e.StatusCode)
+}
+
+// retryable tells whether another mirror may succeed where the failed one did not
+func retryable(err error) bool {
+	var (
+		ce connectionError
+		se serverError
+	)
+
+	return errors.As(err, &ce) || errors.As(err, &se)
+}
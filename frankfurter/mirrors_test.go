@@ -0,0 +1,95 @@
+package frankfurter_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Mirrors", func() {
+	var (
+		servers []*httptest.Server
+		served  []int
+		order   []int // of the servers as they served
+		mirrors *frankfurter.Mirrors
+		service frankfurter.ExchangeRatesService
+	)
+
+	BeforeEach(func() {
+		servers = nil
+		served = make([]int, 3)
+		order = nil
+
+		for i := range served {
+			i := i
+
+			servers = append(servers, httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if i == 0 {
+					time.Sleep(50 * time.Millisecond)
+				}
+
+				served[i]++
+				order = append(order, i)
+				fmt.Fprintln(w, `{ "amount": 1.0, "base": "EUR", "date": "2024-01-16", "rates": { "USD": 1.0882 } }`)
+			})))
+		}
+
+		mirrors = &frankfurter.Mirrors{URLs: []string{servers[0].URL, servers[1].URL, servers[2].URL}}
+	})
+
+	JustBeforeEach(func(ctx SpecContext) {
+		service = frankfurter.ExchangeRatesService{HttpClient: http.DefaultClient, Mirrors: mirrors}
+
+		for i := 0; i < 3; i++ {
+			_, err := service.Latest(ctx)
+			Expect(err).ToNot(HaveOccurred())
+		}
+	})
+
+	AfterEach(func() {
+		for _, server := range servers {
+			server.Close()
+		}
+	})
+
+	Context("failover", func() {
+		It("always uses the first mirror", func() {
+			Expect(served).To(Equal([]int{3, 0, 0}))
+		})
+	})
+
+	Context("round-robin", func() {
+		BeforeEach(func() {
+			mirrors.Strategy = frankfurter.RoundRobin
+		})
+
+		It("spreads the requests", func() {
+			Expect(served).To(Equal([]int{1, 1, 1}))
+		})
+
+		Context("with an offset", func() {
+			BeforeEach(func() {
+				mirrors.Offset = 4
+			})
+
+			It("starts with the mirror at the offset", func() {
+				Expect(order).To(Equal([]int{1, 2, 0}))
+			})
+		})
+	})
+
+	Context("fastest", func() {
+		BeforeEach(func() {
+			mirrors.Strategy = frankfurter.Fastest
+		})
+
+		It("avoids the slow mirror after probing", func() {
+			Expect(served[0]).To(Equal(1)) // the probe only
+		})
+	})
+})
@@ -1,16 +1,32 @@
 package frankfurter
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sort"
 	"strconv"
 	"time"
+
+	"github.com/suhlig/euro-exchange-rates-resource/calendar"
 )
 
 type ExchangeRatesService struct {
 	HttpClient *http.Client
 	URL        string
+
+	// Governor optionally coalesces identical concurrent requests and limits the request rate
+	Governor *Governor
+
+	// Mirrors optionally replaces URL with a set of mirrors that are tried in turn
+	Mirrors *Mirrors
+
+	// Base optionally converts the rates to another base currency than EUR
+	Base Currency
+
+	// Amount optionally multiplies the rates; defaults to 1
+	Amount float32
 }
 
 type ExchangeRates struct {
@@ -28,32 +44,81 @@ type History struct {
 	Rates  RatesAt `json:"rates"`
 }
 
+// Dates returns the dates of the history in chronological order (oldest first)
+func (h History) Dates() []YMD {
+	dates := make([]YMD, 0, len(h.Rates))
+
+	for date := range h.Rates {
+		dates = append(dates, date)
+	}
+
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+
+	return dates
+}
+
+// Sampled tells whether the history skips publication dates, which Frankfurter does for long ranges. A gap between
+// two consecutive dates that is larger than calendar.MaxPublicationGap means that the time series was downsampled.
+func (h History) Sampled() bool {
+	dates := h.Dates()
+
+	for i := 1; i < len(dates); i++ {
+		if days(dates[i-1], dates[i]) > calendar.MaxPublicationGap {
+			return true
+		}
+	}
+
+	return false
+}
+
+// merge combines two histories of the same base and amount
+func (h *History) merge(other *History) *History {
+	merged := &History{
+		Amount: h.Amount,
+		Base:   h.Base,
+		Start:  h.Start,
+		End:    h.End,
+		Rates:  make(RatesAt, len(h.Rates)+len(other.Rates)),
+	}
+
+	if merged.Start.IsZero() || (!other.Start.IsZero() && other.Start.Before(merged.Start)) {
+		merged.Start = other.Start
+	}
+
+	if merged.End.Before(other.End) {
+		merged.End = other.End
+	}
+
+	for date, rates := range h.Rates {
+		merged.Rates[date] = rates
+	}
+
+	for date, rates := range other.Rates {
+		merged.Rates[date] = rates
+	}
+
+	return merged
+}
+
 type Rates map[Currency]float32
 type RatesAt map[YMD]Rates
 type Currency string
 
 // UnmarshalJSON provides custom unmarshaling as we cannot naiively unmarshal a map with time.Time keys.
 func (ra *RatesAt) UnmarshalJSON(raw []byte) error {
-	var rates map[string]Rates
-
-	err := json.Unmarshal(raw, &rates)
-
-	if err != nil {
-		return fmt.Errorf("could not unmarshal rates: %w", err)
-	}
-
 	if *ra == nil {
 		*ra = make(RatesAt)
 	}
 
-	for k, v := range rates {
-		ymd, err := NewYMD(k)
-
-		if err != nil {
-			return err
-		}
+	err := decodeRatesAt(json.NewDecoder(bytes.NewReader(raw)), func(date YMD, rates Rates) error {
+		(*ra)[date] = rates
+		return nil
+	})
 
-		(*ra)[ymd] = v
+	if err != nil {
+		return fmt.Errorf("could not unmarshal rates: %w", err)
 	}
 
 	return nil
@@ -69,19 +134,13 @@ func (ra *RatesAt) UnmarshalJSON(raw []byte) error {
 type YMD time.Time
 
 func NewYMD(s string) (YMD, error) {
-	frankfurt, err := time.LoadLocation("Europe/Berlin")
-
-	if err != nil {
-		return YMD{}, err
-	}
-
-	result, err := time.ParseInLocation(time.DateOnly, s, frankfurt)
+	result, err := time.ParseInLocation(time.DateOnly, s, calendar.Frankfurt)
 
 	if err != nil {
 		return YMD{}, fmt.Errorf("unable to interpret '%s' as YYYY-MM-DD format: %w", s, err)
 	}
 
-	result = result.Add(16 * time.Hour)
+	result = result.Add(calendar.PublicationTime)
 
 	return YMD(result), nil
 }
@@ -94,6 +153,11 @@ func (d YMD) Before(u YMD) bool {
 	return time.Time(d).Before(time.Time(u))
 }
 
+// AddDays returns the date that is n calendar days after d (or before, if n is negative)
+func (d YMD) AddDays(n int) YMD {
+	return YMD(time.Time(d).AddDate(0, 0, n))
+}
+
 func (d YMD) IsZero() bool {
 	return time.Time(d).IsZero()
 }
@@ -0,0 +1,130 @@
+package frankfurter
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Period is the length of the candles that Resample builds
+type Period string
+
+const (
+	Weekly  Period = "weekly"  // Monday to Sunday
+	Monthly Period = "monthly" // first to last day of the calendar month
+)
+
+// Candle summarizes the rates of a currency within a period
+type Candle struct {
+	Currency     Currency `json:"currency"`
+	Start        YMD      `json:"start"` // first day of the period
+	End          YMD      `json:"end"`   // last day of the period
+	Open         float32  `json:"open"`
+	High         float32  `json:"high"`
+	Low          float32  `json:"low"`
+	Close        float32  `json:"close"`
+	Observations int      `json:"observations"`
+
+	// Partial is true if the history does not span the whole period, as it starts after the first or ends before the
+	// last day of the period. Its candle may change once the history is extended.
+	Partial bool `json:"partial"`
+}
+
+// Resample builds open/high/low/close candles per currency and period from the daily rates of the history. The
+// history is assumed to span the range from Start until End, which tells whether the candles at the edges are
+// partial. Candles are ordered by period, then by currency.
+func (h History) Resample(period Period) ([]Candle, error) {
+	bounds, err := period.bounds()
+
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		candles []Candle
+		index   = make(map[string]int) // position in candles by period start and currency
+	)
+
+	for _, date := range h.Dates() {
+		start, end := bounds(time.Time(date))
+		rates := h.Rates[date]
+		currencies := make([]Currency, 0, len(rates))
+
+		for currency := range rates {
+			currencies = append(currencies, currency)
+		}
+
+		slices.Sort(currencies)
+
+		for _, currency := range currencies {
+			rate := rates[currency]
+			key := start.String() + " " + string(currency)
+			i, found := index[key]
+
+			if !found {
+				index[key] = len(candles)
+				candles = append(candles, Candle{
+					Currency: currency,
+					Start:    start,
+					End:      end,
+					Open:     rate,
+					High:     rate,
+					Low:      rate,
+					Partial:  h.partial(start, end),
+				})
+
+				i = len(candles) - 1
+			}
+
+			candle := &candles[i]
+			candle.High = max(candle.High, rate)
+			candle.Low = min(candle.Low, rate)
+			candle.Close = rate
+			candle.Observations++
+		}
+	}
+
+	slices.SortFunc(candles, func(a, b Candle) int {
+		if c := cmp.Compare(a.Start.String(), b.Start.String()); c != 0 {
+			return c
+		}
+
+		return cmp.Compare(a.Currency, b.Currency)
+	})
+
+	return candles, nil
+}
+
+func (h History) partial(start, end YMD) bool {
+	first, last := h.Start, h.End
+	dates := h.Dates()
+
+	if first.IsZero() && len(dates) > 0 {
+		first = dates[0]
+	}
+
+	if last.IsZero() && len(dates) > 0 {
+		last = dates[len(dates)-1]
+	}
+
+	return start.String() < first.String() || last.String() < end.String()
+}
+
+// bounds returns a function that tells the first and last day of the period containing a point in time
+func (p Period) bounds() (func(time.Time) (YMD, YMD), error) {
+	switch p {
+	case Weekly:
+		return func(t time.Time) (YMD, YMD) {
+			monday := t.AddDate(0, 0, -((int(t.Weekday()) + 6) % 7))
+			return YMD(monday), YMD(monday.AddDate(0, 0, 6))
+		}, nil
+	case Monthly:
+		return func(t time.Time) (YMD, YMD) {
+			first := time.Date(t.Year(), t.Month(), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+			return YMD(first), YMD(first.AddDate(0, 1, -1))
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown period %q; must be %s or %s", p, Weekly, Monthly)
+	}
+}
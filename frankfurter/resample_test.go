@@ -0,0 +1,129 @@
+package frankfurter_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/suhlig/euro-exchange-rates-resource/frankfurter"
+)
+
+var _ = Describe("Resample", func() {
+	var (
+		history frankfurter.History
+		period  frankfurter.Period
+		candles []frankfurter.Candle
+		err     error
+	)
+
+	BeforeEach(func() {
+		err := json.Unmarshal([]byte(`
+			{
+				"amount": 1.0,
+				"base": "EUR",
+				"start_date": "2024-01-25",
+				"end_date": "2024-02-06",
+				"rates": {
+					"2024-01-25": { "USD": 1.0893, "SEK": 11.3245 },
+					"2024-01-26": { "USD": 1.0871, "SEK": 11.3095 },
+					"2024-01-29": { "USD": 1.0824 },
+					"2024-01-30": { "USD": 1.0837, "SEK": 11.3095 },
+					"2024-01-31": { "USD": 1.0837, "SEK": 11.2465 },
+					"2024-02-01": { "USD": 1.0814, "SEK": 11.2385 },
+					"2024-02-02": { "USD": 1.0883, "SEK": 11.2585 },
+					"2024-02-05": { "USD": 1.0769, "SEK": 11.3245 },
+					"2024-02-06": { "USD": 1.0745, "SEK": 11.3665 }
+				}
+			}
+		`), &history)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		candles, err = history.Resample(period)
+	})
+
+	Context("weekly", func() {
+		BeforeEach(func() {
+			period = frankfurter.Weekly
+		})
+
+		It("works", func() {
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("builds a candle per week and currency", func() {
+			Expect(candles).To(HaveLen(6))
+		})
+
+		It("orders the candles by period, then by currency", func() {
+			Expect(candles[0].Start.String()).To(Equal("2024-01-22"))
+			Expect(candles[0].Currency).To(Equal(frankfurter.Currency("SEK")))
+			Expect(candles[1].Currency).To(Equal(frankfurter.Currency("USD")))
+			Expect(candles[2].Start.String()).To(Equal("2024-01-29"))
+		})
+
+		It("marks the weeks at the edges as partial", func() {
+			Expect(candles[0].Partial).To(BeTrue())
+			Expect(candles[2].Partial).To(BeFalse())
+			Expect(candles[5].Partial).To(BeTrue())
+		})
+
+		It("computes open, high, low and close", func() {
+			usd := candles[3]
+
+			Expect(usd.Currency).To(Equal(frankfurter.Currency("USD")))
+			Expect(usd.Start.String()).To(Equal("2024-01-29"))
+			Expect(usd.End.String()).To(Equal("2024-02-04"))
+			Expect(usd.Open).To(BeNumerically("==", float32(1.0824)))
+			Expect(usd.High).To(BeNumerically("==", float32(1.0883)))
+			Expect(usd.Low).To(BeNumerically("==", float32(1.0814)))
+			Expect(usd.Close).To(BeNumerically("==", float32(1.0883)))
+			Expect(usd.Observations).To(Equal(5))
+		})
+
+		It("counts only the days with a rate for the currency", func() {
+			Expect(candles[2].Observations).To(Equal(4))
+			Expect(candles[2].Open).To(BeNumerically("==", float32(11.3095)))
+		})
+	})
+
+	Context("monthly", func() {
+		BeforeEach(func() {
+			period = frankfurter.Monthly
+		})
+
+		It("builds a candle per month and currency", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(candles).To(HaveLen(4))
+			Expect(candles[1].Start.String()).To(Equal("2024-01-01"))
+			Expect(candles[1].End.String()).To(Equal("2024-01-31"))
+			Expect(candles[1].Observations).To(Equal(5))
+		})
+
+		It("marks both months as partial", func() {
+			Expect(candles).To(HaveEach(HaveField("Partial", BeTrue())))
+		})
+
+		Context("spanning a whole month", func() {
+			BeforeEach(func() {
+				history.Start, _ = frankfurter.NewYMD("2024-01-01")
+				history.End, _ = frankfurter.NewYMD("2024-02-29")
+			})
+
+			It("is not partial", func() {
+				Expect(candles).To(HaveEach(HaveField("Partial", BeFalse())))
+			})
+		})
+	})
+
+	Context("unknown period", func() {
+		BeforeEach(func() {
+			period = frankfurter.Period("daily")
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring(`unknown period "daily"`)))
+		})
+	})
+})
@@ -15,7 +15,10 @@ func main() {
 		HttpClient: http.DefaultClient,
 	}
 
-	if err := concourse.NewRootCommand(&resource, "Euro Exchange Rates resource").Execute(); err != nil {
+	rootCommand := concourse.NewRootCommand(&resource, "Euro Exchange Rates resource")
+	rootCommand.AddCommand(batchCommand())
+
+	if err := rootCommand.Execute(); err != nil {
 		os.Exit(1)
 	}
 }